@@ -58,6 +58,14 @@ type Informers interface {
 	// of the underlying object.
 	GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind) (Informer, error)
 
+	// RemoveInformer stops the informer for the given object and removes it
+	// from the cache, along with everything it stored.  Event handlers added
+	// to the informer stop receiving events; those implementing
+	// InformerRemovedHandler are notified.  A later GetInformer call creates
+	// a new informer.  It is not an error to remove an informer that doesn't
+	// exist.
+	RemoveInformer(ctx context.Context, obj client.Object) error
+
 	// Start runs all the informers known to this cache until the context is closed.
 	// It blocks.
 	Start(ctx context.Context) error
@@ -69,6 +77,14 @@ type Informers interface {
 	client.FieldIndexer
 }
 
+// InformerRemovedHandler is an optional interface for the event handlers added
+// to an Informer.  Handlers implementing it are called once the informer has
+// been stopped and removed with RemoveInformer.  OnInformerRemoved is called
+// synchronously by RemoveInformer, so it must not block.
+type InformerRemovedHandler interface {
+	OnInformerRemoved()
+}
+
 // Informer - informer allows you interact with the underlying informer
 type Informer interface {
 	// AddEventHandler adds an event handler to the shared informer using the shared informer's resync
@@ -103,6 +119,25 @@ type Options struct {
 	// Namespace restricts the cache's ListWatch to the desired namespace
 	// Default watches all namespaces
 	Namespace string
+
+	// SelectorsByObject restricts the cache's ListWatch to the objects matching
+	// the given label and field selectors, per GVK of the map's keys.
+	// Objects filtered out by a selector are never stored in the cache, so
+	// they can't be read from it either.
+	// Default watches all objects of every type.
+	SelectorsByObject SelectorsByObject
+
+	// TransformByObject registers, per GVK of the map's keys, a function run
+	// on every object before it is stored in the cache.  This can be used to
+	// drop fields that are never read (e.g. managedFields) and reduce the
+	// cache's memory usage.  Readers only ever see the transformed objects.
+	//
+	// The function is called with the object as the informer decoded it: a
+	// typed object, an *unstructured.Unstructured or a
+	// *metav1.PartialObjectMetadata, depending on which kind of informer is
+	// used, and must return an object of the same type.  It may mutate and
+	// return its argument.
+	TransformByObject TransformByObject
 }
 
 var defaultResyncTime = 10 * time.Hour
@@ -113,10 +148,46 @@ func New(config *rest.Config, opts Options) (Cache, error) {
 	if err != nil {
 		return nil, err
 	}
-	im := internal.NewInformersMap(config, opts.Scheme, opts.Mapper, *opts.Resync, opts.Namespace)
+	selectorsByGVK, err := convertToSelectorsByGVK(opts.SelectorsByObject, opts.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	transformByGVK, err := convertToTransformByGVK(opts.TransformByObject, opts.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	im := internal.NewInformersMap(config, opts.Scheme, opts.Mapper, *opts.Resync, opts.Namespace, selectorsByGVK, transformByGVK)
 	return &informerCache{InformersMap: im}, nil
 }
 
+// BuilderWithOptions returns a Cache constructor that fills in any option left
+// unset by the caller (usually the manager) from the given options.  This is
+// useful to specify options like SelectorsByObject when the cache is built
+// by someone else.
+func BuilderWithOptions(options Options) NewCacheFunc {
+	return func(config *rest.Config, opts Options) (Cache, error) {
+		if opts.Scheme == nil {
+			opts.Scheme = options.Scheme
+		}
+		if opts.Mapper == nil {
+			opts.Mapper = options.Mapper
+		}
+		if opts.Resync == nil {
+			opts.Resync = options.Resync
+		}
+		if opts.Namespace == "" {
+			opts.Namespace = options.Namespace
+		}
+		if opts.SelectorsByObject == nil {
+			opts.SelectorsByObject = options.SelectorsByObject
+		}
+		if opts.TransformByObject == nil {
+			opts.TransformByObject = options.TransformByObject
+		}
+		return New(config, opts)
+	}
+}
+
 func defaultOpts(config *rest.Config, opts Options) (Options, error) {
 	// Use the default Kubernetes Scheme if unset
 	if opts.Scheme == nil {
@@ -139,3 +210,39 @@ func defaultOpts(config *rest.Config, opts Options) (Options, error) {
 	}
 	return opts, nil
 }
+
+// SelectorsByObject associate a client.Object's GVK to a field/label selector.
+type SelectorsByObject map[client.Object]ObjectSelector
+
+// ObjectSelector is an alias name of internal.Selector.
+type ObjectSelector internal.Selector
+
+func convertToSelectorsByGVK(selectors SelectorsByObject, scheme *runtime.Scheme) (internal.SelectorsByGVK, error) {
+	selectorsByGVK := internal.SelectorsByGVK{}
+	for object, selector := range selectors {
+		gvk, err := apiutil.GVKForObject(object, scheme)
+		if err != nil {
+			return nil, err
+		}
+		selectorsByGVK[gvk] = internal.Selector(selector)
+	}
+	return selectorsByGVK, nil
+}
+
+// TransformFunc transforms an object before it is stored in the cache.
+type TransformFunc func(obj interface{}) (interface{}, error)
+
+// TransformByObject associate a client.Object's GVK to a transform function.
+type TransformByObject map[client.Object]TransformFunc
+
+func convertToTransformByGVK(transformers TransformByObject, scheme *runtime.Scheme) (internal.TransformFuncByGVK, error) {
+	transformByGVK := internal.TransformFuncByGVK{}
+	for object, transform := range transformers {
+		gvk, err := apiutil.GVKForObject(object, scheme)
+		if err != nil {
+			return nil, err
+		}
+		transformByGVK[gvk] = internal.TransformFunc(transform)
+	}
+	return transformByGVK, nil
+}
@@ -19,6 +19,7 @@ package cache_test
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -26,6 +27,8 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	kscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -235,6 +238,120 @@ func CacheTest(createCacheFunc func(config *rest.Config, opts cache.Options) (ca
 					Expect(actual.Namespace).To(Equal(testNamespaceOne))
 				})
 
+				It("should be able to paginate list results", func() {
+					By("listing all pods at once")
+					all := &kcorev1.PodList{}
+					Expect(informerCache.List(context.Background(), all)).To(Succeed())
+					Expect(all.Items).NotTo(BeEmpty())
+					var allNames []string
+					for _, pod := range all.Items {
+						allNames = append(allNames, pod.Namespace+"/"+pod.Name)
+					}
+
+					By("listing all pods one page at a time")
+					var pagedNames []string
+					page := &kcorev1.PodList{}
+					for {
+						Expect(informerCache.List(context.Background(), page, client.Limit(1), client.Continue(page.Continue))).To(Succeed())
+						Expect(len(page.Items)).To(BeNumerically("<=", 1))
+						for _, pod := range page.Items {
+							pagedNames = append(pagedNames, pod.Namespace+"/"+pod.Name)
+						}
+						if page.Continue == "" {
+							break
+						}
+					}
+
+					By("verifying every pod was returned exactly once, in order")
+					Expect(pagedNames).To(ConsistOf(allNames))
+					Expect(sort.StringsAreSorted(pagedNames)).To(BeTrue())
+				})
+
+				It("should be able to restrict cache to objects matching selectors", func() {
+					By("creating a cache restricted by label and field selectors")
+					selectorCache, err := cache.New(cfg, cache.Options{
+						SelectorsByObject: cache.SelectorsByObject{
+							&kcorev1.Pod{}: {
+								Label: labels.SelectorFromSet(labels.Set{"test-label": "test-pod-2"}),
+								Field: fields.SelectorFromSet(fields.Set{"metadata.namespace": testNamespaceTwo}),
+							},
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					By("running the cache and waiting for it to sync")
+					go func() {
+						defer GinkgoRecover()
+						Expect(selectorCache.Start(informerCacheCtx)).To(Succeed())
+					}()
+					Expect(selectorCache.WaitForCacheSync(informerCacheCtx)).NotTo(BeFalse())
+
+					By("listing pods in all namespaces")
+					out := &kcorev1.PodList{}
+					Expect(selectorCache.List(context.Background(), out)).To(Succeed())
+
+					By("verifying only the pod matching the selectors was cached")
+					Expect(out.Items).Should(HaveLen(1))
+					Expect(out.Items[0].Name).To(Equal("test-pod-2"))
+					Expect(out.Items[0].Namespace).To(Equal(testNamespaceTwo))
+
+					By("verifying a pod filtered out by the selectors is not found")
+					pod := &kcorev1.Pod{}
+					key := client.ObjectKey{Namespace: testNamespaceTwo, Name: "test-pod-3"}
+					err = selectorCache.Get(context.Background(), key, pod)
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+
+					By("verifying types without a selector are not restricted")
+					nodeList := &kcorev1.NodeList{}
+					Expect(selectorCache.List(context.Background(), nodeList)).To(Succeed())
+					Expect(nodeList.Items).NotTo(BeEmpty())
+				})
+
+				It("should transform objects before storing them", func() {
+					By("creating a cache that drops pod labels and container images")
+					transformCache, err := cache.New(cfg, cache.Options{
+						TransformByObject: cache.TransformByObject{
+							&kcorev1.Pod{}: func(obj interface{}) (interface{}, error) {
+								pod, ok := obj.(*kcorev1.Pod)
+								if !ok {
+									return nil, fmt.Errorf("unexpected type %T", obj)
+								}
+								pod.Labels = nil
+								for i := range pod.Spec.Containers {
+									pod.Spec.Containers[i].Image = ""
+								}
+								return pod, nil
+							},
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					By("running the cache and waiting for it to sync")
+					go func() {
+						defer GinkgoRecover()
+						Expect(transformCache.Start(informerCacheCtx)).To(Succeed())
+					}()
+					Expect(transformCache.WaitForCacheSync(informerCacheCtx)).NotTo(BeFalse())
+
+					By("verifying listed pods have been transformed")
+					out := &kcorev1.PodList{}
+					Expect(transformCache.List(context.Background(), out, client.InNamespace(testNamespaceOne))).To(Succeed())
+					Expect(out.Items).Should(HaveLen(1))
+					Expect(out.Items[0].Labels).To(BeEmpty())
+					Expect(out.Items[0].Spec.Containers[0].Image).To(BeEmpty())
+					Expect(out.Items[0].Spec.Containers[0].Name).To(Equal("nginx"))
+
+					By("verifying pods added after the initial list are transformed too")
+					pod := createPod("test-pod-transform", testNamespaceOne, kcorev1.RestartPolicyNever)
+					defer deletePod(pod)
+					cachedPod := &kcorev1.Pod{}
+					Eventually(func() error {
+						return transformCache.Get(context.Background(), client.ObjectKeyFromObject(pod), cachedPod)
+					}).Should(Succeed())
+					Expect(cachedPod.Labels).To(BeEmpty())
+					Expect(cachedPod.Spec.Containers[0].Image).To(BeEmpty())
+				})
+
 				It("should deep copy the object unless told otherwise", func() {
 					By("retrieving a specific pod from the cache")
 					out := &kcorev1.Pod{}
@@ -251,6 +368,42 @@ func CacheTest(createCacheFunc func(config *rest.Config, opts cache.Options) (ca
 					Expect(out).NotTo(Equal(knownPod2))
 				})
 
+				It("should return objects shared with the store if deep copy is disabled", func() {
+					By("retrieving a specific pod from the cache twice")
+					podKey := client.ObjectKey{Name: "test-pod-2", Namespace: testNamespaceTwo}
+					out1 := &kcorev1.Pod{}
+					Expect(informerCache.Get(context.Background(), podKey, out1, client.UnsafeDisableDeepCopy)).To(Succeed())
+					out2 := &kcorev1.Pod{}
+					Expect(informerCache.Get(context.Background(), podKey, out2, client.UnsafeDisableDeepCopy)).To(Succeed())
+
+					By("verifying both results share the cached data")
+					Expect(out1.Spec.ActiveDeadlineSeconds).To(BeIdenticalTo(out2.Spec.ActiveDeadlineSeconds))
+
+					By("listing pods from the cache twice")
+					list1 := &kcorev1.PodList{}
+					Expect(informerCache.List(context.Background(), list1, client.InNamespace(testNamespaceTwo), client.UnsafeDisableDeepCopy)).To(Succeed())
+					list2 := &kcorev1.PodList{}
+					Expect(informerCache.List(context.Background(), list2, client.InNamespace(testNamespaceTwo), client.UnsafeDisableDeepCopy)).To(Succeed())
+
+					By("verifying both results share the cached data")
+					Expect(list1.Items).NotTo(BeEmpty())
+					Expect(list1.Items).To(HaveLen(len(list2.Items)))
+					shared := map[string]*int64{}
+					for _, pod := range list1.Items {
+						shared[pod.Name] = pod.Spec.ActiveDeadlineSeconds
+					}
+					for _, pod := range list2.Items {
+						Expect(pod.Spec.ActiveDeadlineSeconds).To(BeIdenticalTo(shared[pod.Name]))
+					}
+
+					By("verifying results are still copied by default")
+					list3 := &kcorev1.PodList{}
+					Expect(informerCache.List(context.Background(), list3, client.InNamespace(testNamespaceTwo))).To(Succeed())
+					for _, pod := range list3.Items {
+						Expect(pod.Spec.ActiveDeadlineSeconds).NotTo(BeIdenticalTo(shared[pod.Name]))
+					}
+				})
+
 				It("should return an error if the object is not found", func() {
 					By("getting a service that does not exists")
 					svc := &kcorev1.Service{}
@@ -390,6 +543,38 @@ func CacheTest(createCacheFunc func(config *rest.Config, opts cache.Options) (ca
 					Expect(actual.GetNamespace()).To(Equal(testNamespaceOne))
 				})
 
+				It("should be able to restrict cache to objects matching selectors", func() {
+					By("creating a cache restricted by a label selector")
+					selectorCache, err := cache.New(cfg, cache.Options{
+						SelectorsByObject: cache.SelectorsByObject{
+							&kcorev1.Pod{}: {
+								Label: labels.SelectorFromSet(labels.Set{"test-label": "test-pod-1"}),
+							},
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					By("running the cache and waiting for it to sync")
+					go func() {
+						defer GinkgoRecover()
+						Expect(selectorCache.Start(informerCacheCtx)).To(Succeed())
+					}()
+					Expect(selectorCache.WaitForCacheSync(informerCacheCtx)).NotTo(BeFalse())
+
+					By("listing pods in all namespaces")
+					out := &unstructured.UnstructuredList{}
+					out.SetGroupVersionKind(schema.GroupVersionKind{
+						Group:   "",
+						Version: "v1",
+						Kind:    "PodList",
+					})
+					Expect(selectorCache.List(context.Background(), out)).To(Succeed())
+
+					By("verifying only the pod matching the selector was cached")
+					Expect(out.Items).Should(HaveLen(1))
+					Expect(out.Items[0].GetName()).To(Equal("test-pod-1"))
+				})
+
 				It("should be able to restrict cache to a namespace", func() {
 					By("creating a namespaced cache")
 					namespacedCache, err := cache.New(cfg, cache.Options{Namespace: testNamespaceOne})
@@ -600,6 +785,38 @@ func CacheTest(createCacheFunc func(config *rest.Config, opts cache.Options) (ca
 					Expect(actual.GetNamespace()).To(Equal(testNamespaceOne))
 				})
 
+				It("should be able to restrict cache to objects matching selectors", func() {
+					By("creating a cache restricted by a label selector")
+					selectorCache, err := cache.New(cfg, cache.Options{
+						SelectorsByObject: cache.SelectorsByObject{
+							&kcorev1.Pod{}: {
+								Label: labels.SelectorFromSet(labels.Set{"test-label": "test-pod-1"}),
+							},
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					By("running the cache and waiting for it to sync")
+					go func() {
+						defer GinkgoRecover()
+						Expect(selectorCache.Start(informerCacheCtx)).To(Succeed())
+					}()
+					Expect(selectorCache.WaitForCacheSync(informerCacheCtx)).NotTo(BeFalse())
+
+					By("listing pods in all namespaces")
+					out := &kmetav1.PartialObjectMetadataList{}
+					out.SetGroupVersionKind(schema.GroupVersionKind{
+						Group:   "",
+						Version: "v1",
+						Kind:    "PodList",
+					})
+					Expect(selectorCache.List(context.Background(), out)).To(Succeed())
+
+					By("verifying only the pod matching the selector was cached")
+					Expect(out.Items).Should(HaveLen(1))
+					Expect(out.Items[0].GetName()).To(Equal("test-pod-1"))
+				})
+
 				It("should be able to restrict cache to a namespace", func() {
 					By("creating a namespaced cache")
 					namespacedCache, err := cache.New(cfg, cache.Options{Namespace: testNamespaceOne})
@@ -856,6 +1073,29 @@ func CacheTest(createCacheFunc func(config *rest.Config, opts cache.Options) (ca
 					Expect(sii).To(BeNil())
 					Expect(errors.IsTimeout(err)).To(BeTrue())
 				})
+
+				It("should be able to remove an informer", func() {
+					By("getting a shared index informer for a pod and adding a handler")
+					pod := &kcorev1.Pod{}
+					sii, err := informerCache.GetInformer(context.TODO(), pod)
+					Expect(err).NotTo(HaveOccurred())
+					removed := make(chan struct{}, 10)
+					sii.AddEventHandler(&removalTrackingHandler{removed: removed})
+
+					By("removing the informer")
+					Expect(informerCache.RemoveInformer(context.TODO(), pod)).To(Succeed())
+
+					By("verifying the handler was notified")
+					Eventually(removed).Should(Receive())
+
+					By("verifying the cache creates a new informer when asked for pods again")
+					out := &kcorev1.PodList{}
+					Expect(informerCache.List(context.TODO(), out, client.InNamespace(testNamespaceOne))).To(Succeed())
+					Expect(out.Items).NotTo(BeEmpty())
+
+					By("removing an informer that was never created")
+					Expect(informerCache.RemoveInformer(context.TODO(), &kcorev1.ConfigMap{})).To(Succeed())
+				})
 			})
 			Context("with unstructured objects", func() {
 				It("should be able to get informer for the object", func(done Done) {
@@ -1136,3 +1376,14 @@ func isKubeService(svc kmetav1.Object) bool {
 	// grumble grumble linters grumble grumble
 	return svc.GetNamespace() == "default" && svc.GetName() == "kubernetes"
 }
+
+// removalTrackingHandler is an event handler that reports when its informer
+// is removed from the cache.
+type removalTrackingHandler struct {
+	kcache.ResourceEventHandlerFuncs
+	removed chan struct{}
+}
+
+func (h *removalTrackingHandler) OnInformerRemoved() {
+	h.removed <- struct{}{}
+}
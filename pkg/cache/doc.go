@@ -16,4 +16,21 @@ limitations under the License.
 
 // Package cache provides object caches that act as caching client.Reader
 // instances and help drive Kubernetes-object-based event handlers.
+//
+// List requests with a field selector are answered from the indices added
+// with IndexField: equality requirements on indexed fields select the objects
+// of their index, and inequality requirements on indexed fields exclude them.
+// Requirements on fields without an index don't fail; they are evaluated
+// against the content of every object listed, which is a scan of the whole
+// namespace (or cache) unless an indexed requirement narrows it down, and only
+// supports scalar fields.  Add an index for the fields that are selected on
+// often.
+//
+// Lists can be paginated with client.Limit and client.Continue.  Pages are
+// ordered by namespace and name, and each page is served from the current
+// contents of the cache rather than from a snapshot of the first one like the
+// API server does: objects added or deleted between pages may or may not be
+// returned, the others are returned exactly once, and continue tokens never
+// expire with a 410 error.  Every page reports the resource version of the
+// first one.
 package cache
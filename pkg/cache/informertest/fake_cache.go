@@ -79,6 +79,22 @@ func (c *FakeInformers) GetInformer(ctx context.Context, obj client.Object) (cac
 	return c.informerFor(gvk, obj)
 }
 
+// RemoveInformer implements Informers
+func (c *FakeInformers) RemoveInformer(ctx context.Context, obj client.Object) error {
+	if c.Scheme == nil {
+		c.Scheme = scheme.Scheme
+	}
+	gvks, _, err := c.Scheme.ObjectKinds(obj)
+	if err != nil {
+		return err
+	}
+	if i, ok := c.InformersByGVK[gvks[0]].(*controllertest.FakeInformer); ok {
+		i.Remove()
+	}
+	delete(c.InformersByGVK, gvks[0])
+	return nil
+}
+
 // WaitForCacheSync implements Informers
 func (c *FakeInformers) WaitForCacheSync(ctx context.Context) bool {
 	if c.Synced == nil {
@@ -131,7 +147,7 @@ func (c *FakeInformers) IndexField(ctx context.Context, obj client.Object, field
 }
 
 // Get implements Cache
-func (c *FakeInformers) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+func (c *FakeInformers) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
 	return nil
 }
 
@@ -31,6 +31,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/internal/objectutil"
 )
 
 // CacheReader is a client.Reader
@@ -48,8 +49,13 @@ type CacheReader struct {
 	scopeName apimeta.RESTScopeName
 }
 
-// Get checks the indexer for the object and writes a copy of it if found
-func (c *CacheReader) Get(_ context.Context, key client.ObjectKey, out client.Object) error {
+// Get checks the indexer for the object and writes a copy of it if found.
+// If UnsafeDisableDeepCopy is set, the object from the store is written
+// without copying it first; see client.UnsafeDisableDeepCopyOption.
+func (c *CacheReader) Get(_ context.Context, key client.ObjectKey, out client.Object, opts ...client.GetOption) error {
+	getOpts := client.GetOptions{}
+	getOpts.ApplyOptions(opts)
+
 	if c.scopeName == apimeta.RESTScopeNameRoot {
 		key.Namespace = ""
 	}
@@ -76,9 +82,11 @@ func (c *CacheReader) Get(_ context.Context, key client.ObjectKey, out client.Ob
 		return fmt.Errorf("cache contained %T, which is not an Object", obj)
 	}
 
-	// deep copy to avoid mutating cache
-	// TODO(directxman12): revisit the decision to always deepcopy
-	obj = obj.(runtime.Object).DeepCopyObject()
+	disableDeepCopy := getOpts.UnsafeDisableDeepCopy != nil && *getOpts.UnsafeDisableDeepCopy
+	if !disableDeepCopy {
+		// deep copy to avoid mutating cache
+		obj = obj.(runtime.Object).DeepCopyObject()
+	}
 
 	// Copy the value of the item in the cache to the returned value
 	// TODO(directxman12): this is a terrible hack, pls fix (we should have deepcopyinto)
@@ -88,12 +96,19 @@ func (c *CacheReader) Get(_ context.Context, key client.ObjectKey, out client.Ob
 		return fmt.Errorf("cache had type %s, but %s was asked for", objVal.Type(), outVal.Type())
 	}
 	reflect.Indirect(outVal).Set(reflect.Indirect(objVal))
-	out.GetObjectKind().SetGroupVersionKind(c.groupVersionKind)
+	if !disableDeepCopy {
+		// out may share its type information with the store (e.g. the
+		// map of an unstructured object), only set it on copies.
+		out.GetObjectKind().SetGroupVersionKind(c.groupVersionKind)
+	}
 
 	return nil
 }
 
-// List lists items out of the indexer and writes them to out
+// List lists items out of the indexer and writes them to out.
+//
+// Field selectors are resolved with the field indices of the indexer.  Requirements on fields without an index
+// aren't an error: they fall back to a scan evaluating them against each object, see listByFieldSelector.
 func (c *CacheReader) List(_ context.Context, out client.ObjectList, opts ...client.ListOption) error {
 	var objs []interface{}
 	var err error
@@ -101,20 +116,12 @@ func (c *CacheReader) List(_ context.Context, out client.ObjectList, opts ...cli
 	listOpts := client.ListOptions{}
 	listOpts.ApplyOptions(opts)
 
-	if listOpts.FieldSelector != nil {
-		// TODO(directxman12): support more complicated field selectors by
-		// combining multiple indices, GetIndexers, etc
-		field, val, requiresExact := requiresExactMatch(listOpts.FieldSelector)
-		if !requiresExact {
-			return fmt.Errorf("non-exact field matches are not supported by the cache")
-		}
-		// list all objects by the field selector.  If this is namespaced and we have one, ask for the
-		// namespaced index key.  Otherwise, ask for the non-namespaced variant by using the fake "all namespaces"
-		// namespace.
-		objs, err = c.indexer.ByIndex(FieldIndexName(field), KeyToNamespacedKey(listOpts.Namespace, val))
-	} else if listOpts.Namespace != "" {
+	switch {
+	case listOpts.FieldSelector != nil && !listOpts.FieldSelector.Empty():
+		objs, err = c.listByFieldSelector(listOpts.Namespace, listOpts.FieldSelector)
+	case listOpts.Namespace != "":
 		objs, err = c.indexer.ByIndex(cache.NamespaceIndex, listOpts.Namespace)
-	} else {
+	default:
 		objs = c.indexer.List()
 	}
 	if err != nil {
@@ -125,7 +132,7 @@ func (c *CacheReader) List(_ context.Context, out client.ObjectList, opts ...cli
 		labelSel = listOpts.LabelSelector
 	}
 
-	runtimeObjs := make([]runtime.Object, 0, len(objs))
+	matching := make([]runtime.Object, 0, len(objs))
 	for _, item := range objs {
 		obj, isObj := item.(runtime.Object)
 		if !isObj {
@@ -141,12 +148,36 @@ func (c *CacheReader) List(_ context.Context, out client.ObjectList, opts ...cli
 				continue
 			}
 		}
+		matching = append(matching, obj)
+	}
+
+	paginated := listOpts.Limit > 0 || listOpts.Continue != ""
+	var next, resourceVersion string
+	var remaining int64
+	if paginated {
+		if matching, next, remaining, resourceVersion, err = PaginateObjects(matching, listOpts.Limit, listOpts.Continue); err != nil {
+			return err
+		}
+	}
+
+	runtimeObjs := make([]runtime.Object, 0, len(matching))
+	for _, obj := range matching {
+		if listOpts.UnsafeDisableDeepCopy != nil && *listOpts.UnsafeDisableDeepCopy {
+			runtimeObjs = append(runtimeObjs, obj)
+			continue
+		}
 
 		outObj := obj.DeepCopyObject()
 		outObj.GetObjectKind().SetGroupVersionKind(c.groupVersionKind)
 		runtimeObjs = append(runtimeObjs, outObj)
 	}
-	return apimeta.SetList(out, runtimeObjs)
+	if err := apimeta.SetList(out, runtimeObjs); err != nil {
+		return err
+	}
+	if paginated {
+		return SetListContinue(out, next, remaining, resourceVersion)
+	}
+	return nil
 }
 
 // objectKeyToStorageKey converts an object key to store key.
@@ -160,17 +191,130 @@ func objectKeyToStoreKey(k client.ObjectKey) string {
 	return k.Namespace + "/" + k.Name
 }
 
-// requiresExactMatch checks if the given field selector is of the form `k=v` or `k==v`.
-func requiresExactMatch(sel fields.Selector) (field, val string, required bool) {
-	reqs := sel.Requirements()
-	if len(reqs) != 1 {
-		return "", "", false
+// listByFieldSelector lists the objects in the given namespace (or in all
+// namespaces if empty) matching the given field selector.
+//
+// Equality requirements on indexed fields are resolved by intersecting the
+// results of their indices, and inequality requirements on indexed fields by
+// subtracting them.  Requirements on fields without an index are evaluated
+// against each remaining object.
+func (c *CacheReader) listByFieldSelector(namespace string, sel fields.Selector) ([]interface{}, error) {
+	indexers := c.indexer.GetIndexers()
+
+	var matching, excluded, unindexed []fields.Requirement
+	for _, req := range sel.Requirements() {
+		_, hasIndex := indexers[FieldIndexName(req.Field)]
+		switch {
+		case hasIndex && (req.Operator == selection.Equals || req.Operator == selection.DoubleEquals):
+			matching = append(matching, req)
+		case hasIndex && req.Operator == selection.NotEquals:
+			excluded = append(excluded, req)
+		default:
+			unindexed = append(unindexed, req)
+		}
+	}
+
+	var objs []interface{}
+	var err error
+	switch {
+	case len(matching) > 0:
+		for i, req := range matching {
+			// ask for the namespaced index key if we have a namespace.  Otherwise,
+			// ask for the non-namespaced variant by using the fake "all namespaces"
+			// namespace.
+			indexed, err := c.indexer.ByIndex(FieldIndexName(req.Field), KeyToNamespacedKey(namespace, req.Value))
+			if err != nil {
+				return nil, err
+			}
+			if i == 0 {
+				objs = indexed
+				continue
+			}
+			if objs, err = intersectObjects(objs, indexed); err != nil {
+				return nil, err
+			}
+		}
+	case namespace != "":
+		objs, err = c.indexer.ByIndex(cache.NamespaceIndex, namespace)
+	default:
+		objs = c.indexer.List()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, req := range excluded {
+		indexed, err := c.indexer.ByIndex(FieldIndexName(req.Field), KeyToNamespacedKey(namespace, req.Value))
+		if err != nil {
+			return nil, err
+		}
+		if objs, err = subtractObjects(objs, indexed); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(unindexed) == 0 {
+		return objs, nil
+	}
+	filtered := make([]interface{}, 0, len(objs))
+	for _, item := range objs {
+		obj, isObj := item.(runtime.Object)
+		if !isObj {
+			return nil, fmt.Errorf("cache contained %T, which is not an Object", item)
+		}
+		matches, err := objectutil.MatchesFieldRequirements(obj, unindexed)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			filtered = append(filtered, obj)
+		}
+	}
+	return filtered, nil
+}
+
+// intersectObjects returns the objects of a that are also in b.
+func intersectObjects(a, b []interface{}) ([]interface{}, error) {
+	keys, err := objectKeySet(b)
+	if err != nil {
+		return nil, err
+	}
+	return filterObjectsByKey(a, func(key string) bool { return keys[key] })
+}
+
+// subtractObjects returns the objects of a that are not in b.
+func subtractObjects(a, b []interface{}) ([]interface{}, error) {
+	keys, err := objectKeySet(b)
+	if err != nil {
+		return nil, err
 	}
-	req := reqs[0]
-	if req.Operator != selection.Equals && req.Operator != selection.DoubleEquals {
-		return "", "", false
+	return filterObjectsByKey(a, func(key string) bool { return !keys[key] })
+}
+
+func objectKeySet(objs []interface{}) (map[string]bool, error) {
+	keys := make(map[string]bool, len(objs))
+	for _, obj := range objs {
+		key, err := cache.MetaNamespaceKeyFunc(obj)
+		if err != nil {
+			return nil, err
+		}
+		keys[key] = true
+	}
+	return keys, nil
+}
+
+func filterObjectsByKey(objs []interface{}, keep func(key string) bool) ([]interface{}, error) {
+	filtered := make([]interface{}, 0, len(objs))
+	for _, obj := range objs {
+		key, err := cache.MetaNamespaceKeyFunc(obj)
+		if err != nil {
+			return nil, err
+		}
+		if keep(key) {
+			filtered = append(filtered, obj)
+		}
 	}
-	return req.Field, req.Value, true
+	return filtered, nil
 }
 
 // FieldIndexName constructs the name of the index over the given field,
@@ -0,0 +1,335 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fieldIndexFunc indexes pods by the value returned by extract, the same way
+// the informer cache's IndexField does.
+func fieldIndexFunc(extract func(*corev1.Pod) string) cache.IndexFunc {
+	return func(obj interface{}) ([]string, error) {
+		pod := obj.(*corev1.Pod)
+		val := extract(pod)
+		return []string{KeyToNamespacedKey(pod.Namespace, val), KeyToNamespacedKey("", val)}, nil
+	}
+}
+
+func podNames(list *corev1.PodList) []string {
+	names := make([]string, 0, len(list.Items))
+	for _, pod := range list.Items {
+		names = append(names, pod.Name)
+	}
+	return names
+}
+
+var _ = Describe("CacheReader", func() {
+	var reader *CacheReader
+
+	BeforeEach(func() {
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+			cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+			FieldIndexName("spec.nodeName"): fieldIndexFunc(func(pod *corev1.Pod) string {
+				return pod.Spec.NodeName
+			}),
+			FieldIndexName("spec.restartPolicy"): fieldIndexFunc(func(pod *corev1.Pod) string {
+				return string(pod.Spec.RestartPolicy)
+			}),
+		})
+		for _, pod := range []*corev1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-1", Namespace: "ns-1"},
+				Spec:       corev1.PodSpec{NodeName: "node-1", RestartPolicy: corev1.RestartPolicyAlways, ServiceAccountName: "sa-1"},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-2", Namespace: "ns-1"},
+				Spec:       corev1.PodSpec{NodeName: "node-1", RestartPolicy: corev1.RestartPolicyNever, ServiceAccountName: "sa-2"},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-3", Namespace: "ns-2"},
+				Spec:       corev1.PodSpec{NodeName: "node-1", RestartPolicy: corev1.RestartPolicyAlways, ServiceAccountName: "sa-1"},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-4", Namespace: "ns-2"},
+				Spec:       corev1.PodSpec{NodeName: "node-2", RestartPolicy: corev1.RestartPolicyAlways, ServiceAccountName: "sa-2"},
+			},
+		} {
+			Expect(indexer.Add(pod)).To(Succeed())
+		}
+		reader = &CacheReader{
+			indexer:          indexer,
+			groupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+			scopeName:        apimeta.RESTScopeNameNamespace,
+		}
+	})
+
+	Describe("List with field selectors", func() {
+		It("should list objects matching a single indexed field", func() {
+			out := &corev1.PodList{}
+			Expect(reader.List(context.Background(), out, client.MatchingFields{"spec.nodeName": "node-2"})).To(Succeed())
+			Expect(podNames(out)).To(ConsistOf("pod-4"))
+		})
+
+		It("should intersect several indexed fields", func() {
+			out := &corev1.PodList{}
+			Expect(reader.List(context.Background(), out, client.MatchingFields{
+				"spec.nodeName":      "node-1",
+				"spec.restartPolicy": "Always",
+			})).To(Succeed())
+			Expect(podNames(out)).To(ConsistOf("pod-1", "pod-3"))
+		})
+
+		It("should restrict indexed lookups to the given namespace", func() {
+			out := &corev1.PodList{}
+			Expect(reader.List(context.Background(), out, client.InNamespace("ns-2"), client.MatchingFields{
+				"spec.nodeName":      "node-1",
+				"spec.restartPolicy": "Always",
+			})).To(Succeed())
+			Expect(podNames(out)).To(ConsistOf("pod-3"))
+		})
+
+		It("should subtract indexed fields that must not match", func() {
+			out := &corev1.PodList{}
+			Expect(reader.List(context.Background(), out, client.MatchingFieldsSelector{
+				Selector: fields.AndSelectors(
+					fields.OneTermEqualSelector("spec.nodeName", "node-1"),
+					fields.OneTermNotEqualSelector("spec.restartPolicy", "Never"),
+				),
+			})).To(Succeed())
+			Expect(podNames(out)).To(ConsistOf("pod-1", "pod-3"))
+		})
+
+		It("should only subtract when no indexed field must match", func() {
+			out := &corev1.PodList{}
+			Expect(reader.List(context.Background(), out, client.InNamespace("ns-1"), client.MatchingFieldsSelector{
+				Selector: fields.OneTermNotEqualSelector("spec.restartPolicy", "Always"),
+			})).To(Succeed())
+			Expect(podNames(out)).To(ConsistOf("pod-2"))
+		})
+
+		It("should fall back to filtering objects for fields without an index", func() {
+			out := &corev1.PodList{}
+			Expect(reader.List(context.Background(), out, client.MatchingFieldsSelector{
+				Selector: fields.AndSelectors(
+					fields.OneTermEqualSelector("spec.nodeName", "node-1"),
+					fields.OneTermEqualSelector("spec.serviceAccountName", "sa-1"),
+					fields.OneTermNotEqualSelector("metadata.namespace", "ns-2"),
+				),
+			})).To(Succeed())
+			Expect(podNames(out)).To(ConsistOf("pod-1"))
+		})
+
+		It("should treat missing fields without an index as empty", func() {
+			out := &corev1.PodList{}
+			Expect(reader.List(context.Background(), out, client.MatchingFields{"spec.hostname": ""})).To(Succeed())
+			Expect(out.Items).To(HaveLen(4))
+		})
+
+		It("should return an error for fields without an index that are not scalars", func() {
+			out := &corev1.PodList{}
+			err := reader.List(context.Background(), out, client.MatchingFields{"spec": "foo"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("does not refer to a scalar field"))
+		})
+
+		It("should ignore an empty field selector", func() {
+			out := &corev1.PodList{}
+			Expect(reader.List(context.Background(), out, client.MatchingFieldsSelector{Selector: fields.Everything()})).To(Succeed())
+			Expect(out.Items).To(HaveLen(4))
+		})
+
+		It("should evaluate fields of unstructured objects", func() {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			u := &unstructured.Unstructured{}
+			u.SetAPIVersion("v1")
+			u.SetKind("Pod")
+			u.SetNamespace("ns-1")
+			u.SetName("pod-1")
+			Expect(unstructured.SetNestedField(u.Object, "node-1", "spec", "nodeName")).To(Succeed())
+			Expect(indexer.Add(u)).To(Succeed())
+			reader.indexer = indexer
+
+			out := &unstructured.UnstructuredList{}
+			Expect(reader.List(context.Background(), out, client.MatchingFields{"spec.nodeName": "node-1"})).To(Succeed())
+			Expect(out.Items).To(HaveLen(1))
+			Expect(reader.List(context.Background(), out, client.MatchingFields{"spec.nodeName": "node-2"})).To(Succeed())
+			Expect(out.Items).To(BeEmpty())
+		})
+	})
+
+	Describe("List with pagination", func() {
+		It("should page through the objects in a stable order", func() {
+			out := &corev1.PodList{}
+			Expect(reader.List(context.Background(), out, client.Limit(3))).To(Succeed())
+			Expect(podNames(out)).To(Equal([]string{"pod-1", "pod-2", "pod-3"}))
+			Expect(out.Continue).NotTo(BeEmpty())
+			Expect(out.RemainingItemCount).NotTo(BeNil())
+			Expect(*out.RemainingItemCount).To(Equal(int64(1)))
+
+			Expect(reader.List(context.Background(), out, client.Limit(3), client.Continue(out.Continue))).To(Succeed())
+			Expect(podNames(out)).To(Equal([]string{"pod-4"}))
+			Expect(out.Continue).To(BeEmpty())
+			Expect(out.RemainingItemCount).To(BeNil())
+		})
+
+		It("should return everything after the token if no limit is set", func() {
+			out := &corev1.PodList{}
+			Expect(reader.List(context.Background(), out, client.Limit(1))).To(Succeed())
+			Expect(podNames(out)).To(Equal([]string{"pod-1"}))
+
+			Expect(reader.List(context.Background(), out, client.Continue(out.Continue))).To(Succeed())
+			Expect(podNames(out)).To(Equal([]string{"pod-2", "pod-3", "pod-4"}))
+			Expect(out.Continue).To(BeEmpty())
+		})
+
+		It("should not repeat or skip objects when the store changes between pages", func() {
+			out := &corev1.PodList{}
+			Expect(reader.List(context.Background(), out, client.Limit(2))).To(Succeed())
+			Expect(podNames(out)).To(Equal([]string{"pod-1", "pod-2"}))
+
+			By("deleting an object that was already returned")
+			Expect(reader.indexer.Delete(&out.Items[1])).To(Succeed())
+
+			Expect(reader.List(context.Background(), out, client.Limit(2), client.Continue(out.Continue))).To(Succeed())
+			Expect(podNames(out)).To(Equal([]string{"pod-3", "pod-4"}))
+			Expect(out.Continue).To(BeEmpty())
+		})
+
+		It("should report the resource version of the first page on every page", func() {
+			for i, obj := range reader.indexer.List() {
+				pod := obj.(*corev1.Pod).DeepCopy()
+				pod.ResourceVersion = fmt.Sprintf("%d", i+1)
+				Expect(reader.indexer.Update(pod)).To(Succeed())
+			}
+
+			out := &corev1.PodList{}
+			Expect(reader.List(context.Background(), out, client.Limit(2))).To(Succeed())
+			Expect(out.ResourceVersion).To(Equal("4"))
+
+			By("updating an object that wasn't returned yet")
+			pod := &corev1.Pod{}
+			Expect(reader.Get(context.Background(), client.ObjectKey{Namespace: "ns-2", Name: "pod-3"}, pod)).To(Succeed())
+			pod.ResourceVersion = "9"
+			Expect(reader.indexer.Update(pod)).To(Succeed())
+
+			Expect(reader.List(context.Background(), out, client.Limit(2), client.Continue(out.Continue))).To(Succeed())
+			Expect(podNames(out)).To(Equal([]string{"pod-3", "pod-4"}))
+			Expect(out.Items[0].ResourceVersion).To(Equal("9"))
+			Expect(out.ResourceVersion).To(Equal("4"))
+		})
+
+		It("should paginate the objects matching the selectors only", func() {
+			out := &corev1.PodList{}
+			Expect(reader.List(context.Background(), out, client.Limit(1), client.MatchingFields{"spec.nodeName": "node-1"})).To(Succeed())
+			Expect(podNames(out)).To(Equal([]string{"pod-1"}))
+			Expect(*out.RemainingItemCount).To(Equal(int64(2)))
+		})
+
+		It("should reject continue tokens it didn't issue", func() {
+			out := &corev1.PodList{}
+			err := reader.List(context.Background(), out, client.Limit(1), client.Continue("eyJ2IjoibWV0YS5rOHMuaW8vdjEiLCJydiI6MSwic3RhcnQiOiJmb28ifQ"))
+			Expect(apierrors.IsBadRequest(err)).To(BeTrue())
+		})
+	})
+})
+
+// newBenchmarkCacheReader returns a CacheReader over n pods spread across
+// ten namespaces.
+func newBenchmarkCacheReader(b *testing.B, n int) *CacheReader {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+		cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+	})
+	for i := 0; i < n; i++ {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("pod-%d", i),
+				Namespace: fmt.Sprintf("ns-%d", i%10),
+				Labels:    map[string]string{"app": "benchmark"},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}},
+			},
+		}
+		if err := indexer.Add(pod); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return &CacheReader{
+		indexer:          indexer,
+		groupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		scopeName:        apimeta.RESTScopeNameNamespace,
+	}
+}
+
+func BenchmarkCacheReaderList(b *testing.B) {
+	for _, bm := range []struct {
+		name string
+		opts []client.ListOption
+	}{
+		{name: "DeepCopy"},
+		{name: "UnsafeDisableDeepCopy", opts: []client.ListOption{client.UnsafeDisableDeepCopy}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			reader := newBenchmarkCacheReader(b, 5000)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := reader.List(context.Background(), &corev1.PodList{}, bm.opts...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCacheReaderGet(b *testing.B) {
+	for _, bm := range []struct {
+		name string
+		opts []client.GetOption
+	}{
+		{name: "DeepCopy"},
+		{name: "UnsafeDisableDeepCopy", opts: []client.GetOption{client.UnsafeDisableDeepCopy}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			reader := newBenchmarkCacheReader(b, 10)
+			key := client.ObjectKey{Namespace: "ns-1", Name: "pod-1"}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := reader.Get(context.Background(), key, &corev1.Pod{}, bm.opts...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -48,6 +48,8 @@ func newSpecificInformersMap(config *rest.Config,
 	mapper meta.RESTMapper,
 	resync time.Duration,
 	namespace string,
+	selectors SelectorsByGVK,
+	transformers TransformFuncByGVK,
 	createListWatcher createListWatcherFunc) *specificInformersMap {
 	ip := &specificInformersMap{
 		config:            config,
@@ -60,6 +62,8 @@ func newSpecificInformersMap(config *rest.Config,
 		startWait:         make(chan struct{}),
 		createListWatcher: createListWatcher,
 		namespace:         namespace,
+		selectors:         selectors,
+		transformers:      transformers,
 	}
 	return ip
 }
@@ -71,6 +75,74 @@ type MapEntry struct {
 
 	// CacheReader wraps Informer and implements the CacheReader interface for a single type
 	Reader CacheReader
+
+	// stop is closed when the informer is removed from the map, to stop it
+	// independently of the other informers.
+	stop chan struct{}
+
+	// handlers are the event handlers added to Informer, to be notified when
+	// it is removed.
+	handlers *eventHandlers
+}
+
+// run runs the informer until either stop or the entry's own stop channel is
+// closed.
+func (e *MapEntry) run(stop <-chan struct{}) {
+	informerStop := make(chan struct{})
+	go func() {
+		defer close(informerStop)
+		select {
+		case <-stop:
+		case <-e.stop:
+		}
+	}()
+	e.Informer.Run(informerStop)
+}
+
+// eventHandlers records the event handlers added to an informer.
+type eventHandlers struct {
+	mu       sync.Mutex
+	handlers []cache.ResourceEventHandler
+}
+
+func (h *eventHandlers) add(handler cache.ResourceEventHandler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers = append(h.handlers, handler)
+}
+
+// notifyRemoved tells every handler that wants to know that the informer has
+// been removed.
+func (h *eventHandlers) notifyRemoved() {
+	h.mu.Lock()
+	handlers := h.handlers
+	h.handlers = nil
+	h.mu.Unlock()
+
+	for _, handler := range handlers {
+		if removedHandler, ok := handler.(interface{ OnInformerRemoved() }); ok {
+			removedHandler.OnInformerRemoved()
+		}
+	}
+}
+
+// handlerRecordingInformer is a SharedIndexInformer that records the event
+// handlers added to it.
+type handlerRecordingInformer struct {
+	cache.SharedIndexInformer
+	handlers *eventHandlers
+}
+
+// AddEventHandler implements cache.SharedIndexInformer.
+func (i *handlerRecordingInformer) AddEventHandler(handler cache.ResourceEventHandler) {
+	i.handlers.add(handler)
+	i.SharedIndexInformer.AddEventHandler(handler)
+}
+
+// AddEventHandlerWithResyncPeriod implements cache.SharedIndexInformer.
+func (i *handlerRecordingInformer) AddEventHandlerWithResyncPeriod(handler cache.ResourceEventHandler, resyncPeriod time.Duration) {
+	i.handlers.add(handler)
+	i.SharedIndexInformer.AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
 }
 
 // specificInformersMap create and caches Informers for (runtime.Object, schema.GroupVersionKind) pairs.
@@ -120,6 +192,14 @@ type specificInformersMap struct {
 	// namespace is the namespace that all ListWatches are restricted to
 	// default or empty string means all namespaces
 	namespace string
+
+	// selectors are the label or field selectors that will be added to the
+	// ListWatch ListOptions.
+	selectors SelectorsByGVK
+
+	// transformers are the functions run on every object before it is
+	// stored in the informer's indexer.
+	transformers TransformFuncByGVK
 }
 
 // Start calls Run on each of the informers and sets started to true.  Blocks on the context.
@@ -134,7 +214,7 @@ func (ip *specificInformersMap) Start(ctx context.Context) {
 
 		// Start each informer
 		for _, informer := range ip.informersByGVK {
-			go informer.Informer.Run(ctx.Done())
+			go informer.run(ctx.Done())
 		}
 
 		// Set started to true so we immediately start any informers added later.
@@ -209,6 +289,7 @@ func (ip *specificInformersMap) addInformerToMap(gvk schema.GroupVersionKind, ob
 	if err != nil {
 		return nil, false, err
 	}
+	lw = ip.transformers.wrapListWatch(gvk, lw)
 	ni := cache.NewSharedIndexInformer(lw, obj, resyncPeriod(ip.resync)(), cache.Indexers{
 		cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
 	})
@@ -216,9 +297,12 @@ func (ip *specificInformersMap) addInformerToMap(gvk schema.GroupVersionKind, ob
 	if err != nil {
 		return nil, false, err
 	}
+	handlers := &eventHandlers{}
 	i := &MapEntry{
-		Informer: ni,
+		Informer: &handlerRecordingInformer{SharedIndexInformer: ni, handlers: handlers},
 		Reader:   CacheReader{indexer: ni.GetIndexer(), groupVersionKind: gvk, scopeName: rm.Scope.Name()},
+		stop:     make(chan struct{}),
+		handlers: handlers,
 	}
 	ip.informersByGVK[gvk] = i
 
@@ -226,11 +310,31 @@ func (ip *specificInformersMap) addInformerToMap(gvk schema.GroupVersionKind, ob
 	// TODO(seans): write thorough tests and document what happens here - can you add indexers?
 	// can you add eventhandlers?
 	if ip.started {
-		go i.Informer.Run(ip.stop)
+		go i.run(ip.stop)
 	}
 	return i, ip.started, nil
 }
 
+// Remove stops the informer for the given GroupVersionKind, if any, and
+// removes it from the map.  Event handlers added to the informer that
+// implement OnInformerRemoved() are notified once it has been stopped.
+func (ip *specificInformersMap) Remove(gvk schema.GroupVersionKind) {
+	i, ok := func() (*MapEntry, bool) {
+		ip.mu.Lock()
+		defer ip.mu.Unlock()
+		i, ok := ip.informersByGVK[gvk]
+		if ok {
+			delete(ip.informersByGVK, gvk)
+			close(i.stop)
+		}
+		return i, ok
+	}()
+	if !ok {
+		return
+	}
+	i.handlers.notifyRemoved()
+}
+
 // newListWatch returns a new ListWatch object that can be used to create a SharedIndexInformer.
 func createStructuredListWatch(gvk schema.GroupVersionKind, ip *specificInformersMap) (*cache.ListWatch, error) {
 	// Kubernetes APIs work against Resources, not GroupVersionKinds.  Map the
@@ -256,6 +360,7 @@ func createStructuredListWatch(gvk schema.GroupVersionKind, ip *specificInformer
 	// Create a new ListWatch for the obj
 	return &cache.ListWatch{
 		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			ip.selectors.forGVK(gvk).ApplyToList(&opts)
 			res := listObj.DeepCopyObject()
 			isNamespaceScoped := ip.namespace != "" && mapping.Scope.Name() != meta.RESTScopeNameRoot
 			err := client.Get().NamespaceIfScoped(ip.namespace, isNamespaceScoped).Resource(mapping.Resource.Resource).VersionedParams(&opts, ip.paramCodec).Do(ctx).Into(res)
@@ -263,6 +368,7 @@ func createStructuredListWatch(gvk schema.GroupVersionKind, ip *specificInformer
 		},
 		// Setup the watch function
 		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			ip.selectors.forGVK(gvk).ApplyToList(&opts)
 			// Watch needs to be set to true separately
 			opts.Watch = true
 			isNamespaceScoped := ip.namespace != "" && mapping.Scope.Name() != meta.RESTScopeNameRoot
@@ -289,6 +395,7 @@ func createUnstructuredListWatch(gvk schema.GroupVersionKind, ip *specificInform
 	// Create a new ListWatch for the obj
 	return &cache.ListWatch{
 		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			ip.selectors.forGVK(gvk).ApplyToList(&opts)
 			if ip.namespace != "" && mapping.Scope.Name() != meta.RESTScopeNameRoot {
 				return dynamicClient.Resource(mapping.Resource).Namespace(ip.namespace).List(ctx, opts)
 			}
@@ -296,6 +403,7 @@ func createUnstructuredListWatch(gvk schema.GroupVersionKind, ip *specificInform
 		},
 		// Setup the watch function
 		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			ip.selectors.forGVK(gvk).ApplyToList(&opts)
 			// Watch needs to be set to true separately
 			opts.Watch = true
 			if ip.namespace != "" && mapping.Scope.Name() != meta.RESTScopeNameRoot {
@@ -327,6 +435,7 @@ func createMetadataListWatch(gvk schema.GroupVersionKind, ip *specificInformersM
 	// create the relevant listwatch
 	return &cache.ListWatch{
 		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			ip.selectors.forGVK(gvk).ApplyToList(&opts)
 			if ip.namespace != "" && mapping.Scope.Name() != meta.RESTScopeNameRoot {
 				return client.Resource(mapping.Resource).Namespace(ip.namespace).List(ctx, opts)
 			}
@@ -334,6 +443,7 @@ func createMetadataListWatch(gvk schema.GroupVersionKind, ip *specificInformersM
 		},
 		// Setup the watch function
 		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			ip.selectors.forGVK(gvk).ApplyToList(&opts)
 			// Watch needs to be set to true separately
 			opts.Watch = true
 			if ip.namespace != "" && mapping.Scope.Name() != meta.RESTScopeNameRoot {
@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+)
+
+type removalHandler struct {
+	cache.ResourceEventHandlerFuncs
+	removed int
+}
+
+func (h *removalHandler) OnInformerRemoved() {
+	h.removed++
+}
+
+var _ = Describe("specificInformersMap", func() {
+	gvk := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+
+	var ip *specificInformersMap
+	var entry *MapEntry
+
+	BeforeEach(func() {
+		handlers := &eventHandlers{}
+		entry = &MapEntry{
+			Informer: &handlerRecordingInformer{SharedIndexInformer: &controllertest.FakeInformer{}, handlers: handlers},
+			stop:     make(chan struct{}),
+			handlers: handlers,
+		}
+		ip = &specificInformersMap{
+			informersByGVK: map[schema.GroupVersionKind]*MapEntry{gvk: entry},
+			startWait:      make(chan struct{}),
+		}
+	})
+
+	Describe("Remove", func() {
+		It("should stop the informer and drop it from the map", func() {
+			ip.Remove(gvk)
+			Expect(ip.informersByGVK).NotTo(HaveKey(gvk))
+			Expect(entry.stop).To(BeClosed())
+		})
+
+		It("should notify the handlers that want to know", func() {
+			removed := &removalHandler{}
+			entry.Informer.AddEventHandler(removed)
+			entry.Informer.AddEventHandler(cache.ResourceEventHandlerFuncs{})
+
+			ip.Remove(gvk)
+			Expect(removed.removed).To(Equal(1))
+
+			By("not notifying them again if the kind is removed twice")
+			ip.Remove(gvk)
+			Expect(removed.removed).To(Equal(1))
+		})
+
+		It("should do nothing for kinds without an informer", func() {
+			ip.Remove(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"})
+			Expect(ip.informersByGVK).To(HaveKey(gvk))
+			Expect(entry.stop).NotTo(BeClosed())
+		})
+	})
+})
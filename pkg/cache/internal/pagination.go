@@ -0,0 +1,170 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+// continueTokenVersion identifies the format of the continue tokens issued by
+// the cache, so that tokens from the API server are rejected.
+const continueTokenVersion = "cache.controller-runtime.sigs.k8s.io/v1"
+
+// continueToken is the decoded form of the continue tokens issued by the
+// cache.  Objects are paged in the order of their store keys, and a token
+// holds the key of the last object returned, so that the next page starts
+// right after it in the current state of the store.  It also holds the
+// resource version of the first page, which every page of the list reports.
+type continueToken struct {
+	Version         string `json:"v"`
+	ResourceVersion string `json:"rv"`
+	StartKey        string `json:"start"`
+}
+
+func encodeContinueToken(resourceVersion, startKey string) (string, error) {
+	data, err := json.Marshal(continueToken{Version: continueTokenVersion, ResourceVersion: resourceVersion, StartKey: startKey})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeContinueToken(token string) (continueToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return continueToken{}, apierrors.NewBadRequest(fmt.Sprintf("invalid continue token: %v", err))
+	}
+	decoded := continueToken{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return continueToken{}, apierrors.NewBadRequest(fmt.Sprintf("invalid continue token: %v", err))
+	}
+	if decoded.Version != continueTokenVersion {
+		return continueToken{}, apierrors.NewBadRequest(fmt.Sprintf("continue token was not issued by the cache, version %q", decoded.Version))
+	}
+	return decoded, nil
+}
+
+// latestResourceVersion returns the newest resource version of the given
+// objects, i.e. the oldest resource version the store they were listed from
+// can be at.  It returns an empty string if a resource version isn't an
+// integer, since resource versions are opaque.
+func latestResourceVersion(keyed []keyedObject) (string, error) {
+	var latest uint64
+	for _, k := range keyed {
+		meta, err := apimeta.Accessor(k.obj)
+		if err != nil {
+			return "", err
+		}
+		if meta.GetResourceVersion() == "" {
+			continue
+		}
+		rv, err := strconv.ParseUint(meta.GetResourceVersion(), 10, 64)
+		if err != nil {
+			return "", nil
+		}
+		if rv > latest {
+			latest = rv
+		}
+	}
+	if latest == 0 {
+		return "", nil
+	}
+	return strconv.FormatUint(latest, 10), nil
+}
+
+// PaginateObjects returns the page of objs selected by limit and the continue
+// token of the previous page, if any, along with the token for the next page
+// and the number of objects left after it.  The token is empty once the last
+// page has been returned.  A limit of zero returns all remaining objects.
+// The returned resource version is the one of the first page of the list.
+//
+// Unlike the API server, which serves every page from a snapshot of the first
+// one, the cache serves each page from its current contents.  Objects are
+// ordered by their namespace and name, so that pages are stable while the
+// cache changes: objects that are neither added nor deleted meanwhile are
+// returned exactly once, but the others may or may not be, and may be newer
+// than the resource version of the list.  Tokens therefore never expire.
+func PaginateObjects(objs []runtime.Object, limit int64, continueFrom string) (page []runtime.Object, next string, remaining int64, resourceVersion string, err error) {
+	keyed := make([]keyedObject, 0, len(objs))
+	for _, obj := range objs {
+		key, err := cache.MetaNamespaceKeyFunc(obj)
+		if err != nil {
+			return nil, "", 0, "", err
+		}
+		keyed = append(keyed, keyedObject{key: key, obj: obj})
+	}
+	sort.Slice(keyed, func(i, j int) bool { return keyed[i].key < keyed[j].key })
+
+	start := 0
+	if continueFrom != "" {
+		token, err := decodeContinueToken(continueFrom)
+		if err != nil {
+			return nil, "", 0, "", err
+		}
+		resourceVersion = token.ResourceVersion
+		start = sort.Search(len(keyed), func(i int) bool { return keyed[i].key > token.StartKey })
+	} else if resourceVersion, err = latestResourceVersion(keyed); err != nil {
+		return nil, "", 0, "", err
+	}
+	keyed = keyed[start:]
+
+	end := len(keyed)
+	if limit > 0 && int64(len(keyed)) > limit {
+		end = int(limit)
+		if next, err = encodeContinueToken(resourceVersion, keyed[end-1].key); err != nil {
+			return nil, "", 0, "", err
+		}
+		remaining = int64(len(keyed) - end)
+	}
+
+	page = make([]runtime.Object, 0, end)
+	for _, k := range keyed[:end] {
+		page = append(page, k.obj)
+	}
+	return page, next, remaining, resourceVersion, nil
+}
+
+// SetListContinue sets the continue token, remaining item count and resource
+// version of the given list.
+func SetListContinue(list runtime.Object, next string, remaining int64, resourceVersion string) error {
+	listAccessor, err := apimeta.ListAccessor(list)
+	if err != nil {
+		return err
+	}
+	listAccessor.SetContinue(next)
+	listAccessor.SetResourceVersion(resourceVersion)
+	if next == "" {
+		listAccessor.SetRemainingItemCount(nil)
+	} else {
+		listAccessor.SetRemainingItemCount(&remaining)
+	}
+	return nil
+}
+
+type keyedObject struct {
+	key string
+	obj runtime.Object
+}
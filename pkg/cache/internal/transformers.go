@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+// TransformFunc transforms an object before it is stored in the informer's
+// store.  It receives the object as decoded from the apiserver and must
+// return an object of the same type.
+type TransformFunc func(interface{}) (interface{}, error)
+
+// TransformFuncByGVK associate a GroupVersionKind to a transform function.
+type TransformFuncByGVK map[schema.GroupVersionKind]TransformFunc
+
+// forGVK returns the transform function for the given GroupVersionKind, or
+// nil if none was registered.
+func (t TransformFuncByGVK) forGVK(gvk schema.GroupVersionKind) TransformFunc {
+	return t[gvk]
+}
+
+// wrapListWatch returns a ListWatch that runs the transform function
+// registered for gvk on every object returned by lw, so that only transformed
+// objects make it into the informer's store.
+func (t TransformFuncByGVK) wrapListWatch(gvk schema.GroupVersionKind, lw *cache.ListWatch) *cache.ListWatch {
+	transform := t.forGVK(gvk)
+	if transform == nil {
+		return lw
+	}
+
+	return &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			list, err := lw.ListFunc(opts)
+			if err != nil {
+				return nil, err
+			}
+			items, err := meta.ExtractList(list)
+			if err != nil {
+				return nil, err
+			}
+			for i := range items {
+				if items[i], err = transformObject(transform, items[i]); err != nil {
+					return nil, err
+				}
+			}
+			if err := meta.SetList(list, items); err != nil {
+				return nil, err
+			}
+			return list, nil
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			w, err := lw.WatchFunc(opts)
+			if err != nil {
+				return nil, err
+			}
+			return watch.Filter(w, func(in watch.Event) (watch.Event, bool) {
+				if in.Type == watch.Bookmark || in.Type == watch.Error {
+					return in, true
+				}
+				obj, err := transformObject(transform, in.Object)
+				if err != nil {
+					// Surface the failure as an error event: the reflector
+					// will stop this watch and relist, reporting the error.
+					status := apierrors.NewInternalError(err).Status()
+					return watch.Event{Type: watch.Error, Object: &status}, true
+				}
+				in.Object = obj
+				return in, true
+			}), nil
+		},
+	}
+}
+
+func transformObject(transform TransformFunc, obj runtime.Object) (runtime.Object, error) {
+	out, err := transform(obj)
+	if err != nil {
+		return nil, err
+	}
+	transformed, ok := out.(runtime.Object)
+	if !ok {
+		return nil, fmt.Errorf("transform function returned %T, expected a runtime.Object", out)
+	}
+	return transformed, nil
+}
@@ -19,97 +19,389 @@ package cache
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/meta"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
 	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/internal"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
 // NewCacheFunc - Function for creating a new cache from the options and a rest config
 type NewCacheFunc func(config *rest.Config, opts Options) (Cache, error)
 
+// MultiNamespaceCache is a Cache scoped to a set of namespaces that can change
+// while the cache is running.  The caches built by MultiNamespacedCacheBuilder
+// and MultiNamespacedCacheBuilderWithSelector implement it.
+type MultiNamespaceCache interface {
+	Cache
+
+	// AddNamespace starts caching the objects of the given namespace.  The
+	// informers already handed out by the cache, along with their event
+	// handlers and indices, are extended to the new namespace.
+	AddNamespace(namespace string) error
+
+	// RemoveNamespace stops caching the objects of the given namespace.  Event
+	// handlers are not notified about the objects leaving the cache.
+	RemoveNamespace(namespace string) error
+
+	// Namespaces returns the namespaces currently cached.
+	Namespaces() []string
+}
+
 // MultiNamespacedCacheBuilder - Builder function to create a new multi-namespaced cache.
 // This will scope the cache to a list of namespaces. Listing for all namespaces
 // will list for all the namespaces that this knows about. Note that this is not intended
 // to be used for excluding namespaces, this is better done via a Predicate. Also note that
 // you may face performance issues when using this with a high number of namespaces.
+//
+// Cluster-scoped objects (e.g. Nodes) are cached by a single cluster-wide
+// informer.  The list of namespaces can be changed later through the
+// MultiNamespaceCache interface.
 func MultiNamespacedCacheBuilder(namespaces []string) NewCacheFunc {
 	return func(config *rest.Config, opts Options) (Cache, error) {
-		opts, err := defaultOpts(config, opts)
+		return newMultiNamespaceCache(config, opts, namespaces)
+	}
+}
+
+// MultiNamespacedCacheBuilderWithSelector - Builder function to create a new multi-namespaced
+// cache scoped to the namespaces whose labels match the given selector.  Namespaces are
+// added to and removed from the cache as they are created, relabeled or deleted.
+// See MultiNamespacedCacheBuilder.
+func MultiNamespacedCacheBuilderWithSelector(selector labels.Selector) NewCacheFunc {
+	return func(config *rest.Config, opts Options) (Cache, error) {
+		c, err := newMultiNamespaceCache(config, opts, nil)
 		if err != nil {
 			return nil, err
 		}
-		caches := map[string]Cache{}
-		for _, ns := range namespaces {
-			opts.Namespace = ns
-			c, err := New(config, opts)
-			if err != nil {
-				return nil, err
-			}
-			caches[ns] = c
+		// The cluster cache isn't started yet, so this doesn't block.
+		informer, err := c.clusterCache.GetInformer(context.Background(), &corev1.Namespace{})
+		if err != nil {
+			return nil, err
 		}
-		return &multiNamespaceCache{namespaceToCache: caches, Scheme: opts.Scheme}, nil
+		c.namespaceHandler = newNamespaceSelectorHandler(c, selector)
+		informer.AddEventHandler(c.namespaceHandler)
+		return c, nil
 	}
 }
 
+func newMultiNamespaceCache(config *rest.Config, opts Options, namespaces []string) (*multiNamespaceCache, error) {
+	opts, err := defaultOpts(config, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterOpts := opts
+	clusterOpts.Namespace = ""
+	clusterCache, err := New(config, clusterOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &multiNamespaceCache{
+		Scheme:            opts.Scheme,
+		config:            config,
+		opts:              opts,
+		clusterCache:      clusterCache,
+		namespaceToCache:  map[string]Cache{},
+		namespaceToCancel: map[string]context.CancelFunc{},
+		informers:         map[informerKey]*multiNamespaceInformer{},
+	}
+	for _, ns := range namespaces {
+		if err := c.AddNamespace(ns); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
 // multiNamespaceCache knows how to handle multiple namespaced caches
 // Use this feature when scoping permissions for your
 // operator to a list of namespaces instead of watching every namespace
 // in the cluster.
 type multiNamespaceCache struct {
-	namespaceToCache map[string]Cache
-	Scheme           *runtime.Scheme
+	Scheme *runtime.Scheme
+
+	// config and opts are used to create the caches of namespaces added later.
+	config *rest.Config
+	opts   Options
+
+	// clusterCache holds the cluster-scoped objects.
+	clusterCache Cache
+
+	// namespaceHandler follows the namespaces matching a selector, if the
+	// cache was built with MultiNamespacedCacheBuilderWithSelector.
+	namespaceHandler *namespaceSelectorHandler
+
+	// mu guards the fields below.
+	mu sync.RWMutex
+
+	// ctx is the context the cache was started with, nil until then.
+	ctx context.Context
+
+	namespaceToCache  map[string]Cache
+	namespaceToCancel map[string]context.CancelFunc
+
+	// informers are the informers for namespaced kinds handed out so far,
+	// which have to be extended to namespaces added later.
+	informers map[informerKey]*multiNamespaceInformer
+
+	// indexes are the fields indexed so far, which have to be indexed in
+	// namespaces added later too.
+	indexes []fieldIndex
+}
+
+// informerKey identifies an informer: the same kind may be cached as typed,
+// unstructured or metadata-only objects.
+type informerKey struct {
+	gvk     schema.GroupVersionKind
+	objType reflect.Type
+}
+
+type fieldIndex struct {
+	obj          client.Object
+	field        string
+	extractValue client.IndexerFunc
 }
 
-var _ Cache = &multiNamespaceCache{}
+var _ MultiNamespaceCache = &multiNamespaceCache{}
+
+// isClusterScoped uses the RESTMapper to tell whether the given kind is cluster-scoped.
+func (c *multiNamespaceCache) isClusterScoped(gvk schema.GroupVersionKind) (bool, error) {
+	mapping, err := c.opts.Mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return false, err
+	}
+	return mapping.Scope.Name() == apimeta.RESTScopeNameRoot, nil
+}
+
+// caches returns a snapshot of the namespaced caches.
+func (c *multiNamespaceCache) caches() map[string]Cache {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	caches := make(map[string]Cache, len(c.namespaceToCache))
+	for ns, cache := range c.namespaceToCache {
+		caches[ns] = cache
+	}
+	return caches
+}
+
+// AddNamespace implements MultiNamespaceCache
+func (c *multiNamespaceCache) AddNamespace(namespace string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.namespaceToCache[namespace]; ok {
+		return nil
+	}
+
+	opts := c.opts
+	opts.Namespace = namespace
+	cache, err := New(c.config, opts)
+	if err != nil {
+		return err
+	}
+
+	// The new cache isn't started yet, so none of these calls block.
+	for _, index := range c.indexes {
+		if err := cache.IndexField(context.Background(), index.obj, index.field, index.extractValue); err != nil {
+			return err
+		}
+	}
+	for _, informer := range c.informers {
+		i, err := cache.GetInformer(context.Background(), informer.obj)
+		if err != nil {
+			return err
+		}
+		if err := informer.addNamespace(namespace, i); err != nil {
+			return err
+		}
+	}
+
+	c.namespaceToCache[namespace] = cache
+	if c.ctx != nil {
+		c.startNamespace(namespace, cache)
+	}
+	return nil
+}
+
+// startNamespace starts the cache of the given namespace. c.mu must be held.
+func (c *multiNamespaceCache) startNamespace(namespace string, cache Cache) {
+	ctx, cancel := context.WithCancel(c.ctx)
+	c.namespaceToCancel[namespace] = cancel
+	go func() {
+		if err := cache.Start(ctx); err != nil {
+			log.Error(err, "multinamespace cache failed to start namespaced informer", "namespace", namespace)
+		}
+	}()
+}
+
+// RemoveNamespace implements MultiNamespaceCache
+func (c *multiNamespaceCache) RemoveNamespace(namespace string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.namespaceToCache[namespace]; !ok {
+		return nil
+	}
+	delete(c.namespaceToCache, namespace)
+	if cancel, ok := c.namespaceToCancel[namespace]; ok {
+		cancel()
+		delete(c.namespaceToCancel, namespace)
+	}
+	for _, informer := range c.informers {
+		informer.removeNamespace(namespace)
+	}
+	return nil
+}
+
+// Namespaces implements MultiNamespaceCache
+func (c *multiNamespaceCache) Namespaces() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	namespaces := make([]string, 0, len(c.namespaceToCache))
+	for ns := range c.namespaceToCache {
+		namespaces = append(namespaces, ns)
+	}
+	return namespaces
+}
 
 // Methods for multiNamespaceCache to conform to the Informers interface
 func (c *multiNamespaceCache) GetInformer(ctx context.Context, obj client.Object) (Informer, error) {
-	informers := map[string]Informer{}
-	for ns, cache := range c.namespaceToCache {
-		informer, err := cache.GetInformer(ctx, obj)
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	clusterScoped, err := c.isClusterScoped(gvk)
+	if err != nil {
+		return nil, err
+	}
+	if clusterScoped {
+		return c.clusterCache.GetInformer(ctx, obj)
+	}
+
+	key := informerKey{gvk: gvk, objType: reflect.TypeOf(obj)}
+	informer := func() *multiNamespaceInformer {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		informer, ok := c.informers[key]
+		if !ok {
+			informer = &multiNamespaceInformer{obj: obj, namespaceToInformer: map[string]Informer{}}
+			c.informers[key] = informer
+		}
+		return informer
+	}()
+
+	// Don't hold the lock while getting the namespaced informers, as this
+	// blocks until they are synced.
+	for ns, cache := range c.caches() {
+		if informer.hasNamespace(ns) {
+			continue
+		}
+		i, err := cache.GetInformer(ctx, obj)
 		if err != nil {
 			return nil, err
 		}
-		informers[ns] = informer
+		if err := c.addNamespaceInformer(informer, ns, cache, i); err != nil {
+			return nil, err
+		}
+	}
+	return informer, nil
+}
+
+// addNamespaceInformer adds the informer of the given namespace cache to the
+// multi-namespace informer, unless the namespace has been removed meanwhile.
+func (c *multiNamespaceCache) addNamespaceInformer(informer *multiNamespaceInformer, namespace string, cache Cache, i Informer) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.namespaceToCache[namespace] != cache {
+		return nil
 	}
-	return &multiNamespaceInformer{namespaceToInformer: informers}, nil
+	return informer.addNamespace(namespace, i)
 }
 
 func (c *multiNamespaceCache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind) (Informer, error) {
-	informers := map[string]Informer{}
-	for ns, cache := range c.namespaceToCache {
-		informer, err := cache.GetInformerForKind(ctx, gvk)
-		if err != nil {
-			return nil, err
+	obj, err := c.Scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	cObj, ok := obj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a client.Object", obj)
+	}
+	return c.GetInformer(ctx, cObj)
+}
+
+func (c *multiNamespaceCache) RemoveInformer(ctx context.Context, obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme)
+	if err != nil {
+		return err
+	}
+	clusterScoped, err := c.isClusterScoped(gvk)
+	if err != nil {
+		return err
+	}
+	if clusterScoped {
+		return c.clusterCache.RemoveInformer(ctx, obj)
+	}
+
+	c.mu.Lock()
+	delete(c.informers, informerKey{gvk: gvk, objType: reflect.TypeOf(obj)})
+	c.mu.Unlock()
+
+	for _, cache := range c.caches() {
+		if err := cache.RemoveInformer(ctx, obj); err != nil {
+			return err
 		}
-		informers[ns] = informer
 	}
-	return &multiNamespaceInformer{namespaceToInformer: informers}, nil
+	return nil
 }
 
 func (c *multiNamespaceCache) Start(ctx context.Context) error {
-	for ns, cache := range c.namespaceToCache {
-		go func(ns string, cache Cache) {
-			err := cache.Start(ctx)
-			if err != nil {
-				log.Error(err, "multinamespace cache failed to start namespaced informer", "namespace", ns)
+	if err := func() error {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.ctx != nil {
+			return fmt.Errorf("multinamespace cache was already started")
+		}
+		c.ctx = ctx
+
+		go func() {
+			if err := c.clusterCache.Start(ctx); err != nil {
+				log.Error(err, "multinamespace cache failed to start cluster-scoped informer")
 			}
-		}(ns, cache)
+		}()
+		for ns, cache := range c.namespaceToCache {
+			c.startNamespace(ns, cache)
+		}
+		return nil
+	}(); err != nil {
+		return err
 	}
 	<-ctx.Done()
 	return nil
 }
 
 func (c *multiNamespaceCache) WaitForCacheSync(ctx context.Context) bool {
-	synced := true
-	for _, cache := range c.namespaceToCache {
+	synced := c.clusterCache.WaitForCacheSync(ctx)
+	if !synced {
+		return false
+	}
+	// The namespaces matching the selector are only added once the namespace
+	// handler processed them, after the cluster cache synced.
+	if c.namespaceHandler != nil && !c.waitForNamespaceHandler(ctx) {
+		return false
+	}
+	for _, cache := range c.caches() {
 		if s := cache.WaitForCacheSync(ctx); !s {
 			synced = s
 		}
@@ -117,7 +409,38 @@ func (c *multiNamespaceCache) WaitForCacheSync(ctx context.Context) bool {
 	return synced
 }
 
+// waitForNamespaceHandler waits until the namespace handler processed all the
+// namespaces of the synced cluster cache.
+func (c *multiNamespaceCache) waitForNamespaceHandler(ctx context.Context) bool {
+	namespaces := &corev1.NamespaceList{}
+	if err := c.clusterCache.List(ctx, namespaces); err != nil {
+		log.Error(err, "multinamespace cache failed to list namespaces")
+		return false
+	}
+	names := make([]string, 0, len(namespaces.Items))
+	for _, ns := range namespaces.Items {
+		names = append(names, ns.Name)
+	}
+	return c.namespaceHandler.waitForProcessed(ctx, names)
+}
+
 func (c *multiNamespaceCache) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme)
+	if err != nil {
+		return err
+	}
+	clusterScoped, err := c.isClusterScoped(gvk)
+	if err != nil {
+		return err
+	}
+	if clusterScoped {
+		return c.clusterCache.IndexField(ctx, obj, field, extractValue)
+	}
+
+	// Hold the lock so that namespaces added meanwhile get the index exactly once.
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.indexes = append(c.indexes, fieldIndex{obj: obj, field: field, extractValue: extractValue})
 	for _, cache := range c.namespaceToCache {
 		if err := cache.IndexField(ctx, obj, field, extractValue); err != nil {
 			return err
@@ -126,27 +449,55 @@ func (c *multiNamespaceCache) IndexField(ctx context.Context, obj client.Object,
 	return nil
 }
 
-func (c *multiNamespaceCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+func (c *multiNamespaceCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme)
+	if err != nil {
+		return err
+	}
+	clusterScoped, err := c.isClusterScoped(gvk)
+	if err != nil {
+		return err
+	}
+	if clusterScoped {
+		return c.clusterCache.Get(ctx, key, obj, opts...)
+	}
+
+	c.mu.RLock()
 	cache, ok := c.namespaceToCache[key.Namespace]
+	c.mu.RUnlock()
 	if !ok {
 		return fmt.Errorf("unable to get: %v because of unknown namespace for the cache", key)
 	}
-	return cache.Get(ctx, key, obj)
+	return cache.Get(ctx, key, obj, opts...)
 }
 
 // List multi namespace cache will get all the objects in the namespaces that the cache is watching if asked for all namespaces.
 func (c *multiNamespaceCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	gvk, err := apiutil.GVKForObject(list, c.Scheme)
+	if err != nil {
+		return err
+	}
+	clusterScoped, err := c.isClusterScoped(gvk.GroupVersion().WithKind(strings.TrimSuffix(gvk.Kind, "List")))
+	if err != nil {
+		return err
+	}
+	if clusterScoped {
+		return c.clusterCache.List(ctx, list, opts...)
+	}
+
 	listOpts := client.ListOptions{}
 	listOpts.ApplyOptions(opts)
 	if listOpts.Namespace != corev1.NamespaceAll {
+		c.mu.RLock()
 		cache, ok := c.namespaceToCache[listOpts.Namespace]
+		c.mu.RUnlock()
 		if !ok {
 			return fmt.Errorf("unable to get: %v because of unknown namespace for the cache", listOpts.Namespace)
 		}
 		return cache.List(ctx, list, opts...)
 	}
 
-	listAccessor, err := meta.ListAccessor(list)
+	listAccessor, err := apimeta.ListAccessor(list)
 	if err != nil {
 		return err
 	}
@@ -155,8 +506,21 @@ func (c *multiNamespaceCache) List(ctx context.Context, list client.ObjectList,
 	if err != nil {
 		return err
 	}
+
+	// Pages have to span all namespaces, so list everything from each
+	// namespace and paginate the combined result.
+	paginated := listOpts.Limit > 0 || listOpts.Continue != ""
+	if paginated {
+		// The list is usually reused from the previous page.
+		allItems = nil
+		unpaginated := listOpts
+		unpaginated.Limit = 0
+		unpaginated.Continue = ""
+		opts = []client.ListOption{&unpaginated}
+	}
+
 	var resourceVersion string
-	for _, cache := range c.namespaceToCache {
+	for _, cache := range c.caches() {
 		listObj := list.DeepCopyObject().(client.ObjectList)
 		err = cache.List(ctx, listObj, opts...)
 		if err != nil {
@@ -166,7 +530,7 @@ func (c *multiNamespaceCache) List(ctx context.Context, list client.ObjectList,
 		if err != nil {
 			return err
 		}
-		accessor, err := meta.ListAccessor(listObj)
+		accessor, err := apimeta.ListAccessor(listObj)
 		if err != nil {
 			return fmt.Errorf("object: %T must be a list type", list)
 		}
@@ -176,18 +540,82 @@ func (c *multiNamespaceCache) List(ctx context.Context, list client.ObjectList,
 	}
 	listAccessor.SetResourceVersion(resourceVersion)
 
-	return apimeta.SetList(list, allItems)
+	if !paginated {
+		return apimeta.SetList(list, allItems)
+	}
+	page, next, remaining, resourceVersion, err := internal.PaginateObjects(allItems, listOpts.Limit, listOpts.Continue)
+	if err != nil {
+		return err
+	}
+	if err := apimeta.SetList(list, page); err != nil {
+		return err
+	}
+	return internal.SetListContinue(list, next, remaining, resourceVersion)
 }
 
 // multiNamespaceInformer knows how to handle interacting with the underlying informer across multiple namespaces
 type multiNamespaceInformer struct {
+	// obj is the type of object of the informer, used to get the informers
+	// of namespaces added later.
+	obj client.Object
+
+	mu                  sync.RWMutex
 	namespaceToInformer map[string]Informer
+
+	// handlers and indexers are added to the informers of namespaces added later.
+	handlers []eventHandlerWithResync
+	indexers []toolscache.Indexers
+}
+
+type eventHandlerWithResync struct {
+	handler      toolscache.ResourceEventHandler
+	resyncPeriod *time.Duration
 }
 
 var _ Informer = &multiNamespaceInformer{}
 
+func (i *multiNamespaceInformer) hasNamespace(namespace string) bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	_, ok := i.namespaceToInformer[namespace]
+	return ok
+}
+
+// addNamespace adds the informer of a namespace, along with the event handlers
+// and indexers added so far.
+func (i *multiNamespaceInformer) addNamespace(namespace string, informer Informer) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if _, ok := i.namespaceToInformer[namespace]; ok {
+		return nil
+	}
+	for _, indexers := range i.indexers {
+		if err := informer.AddIndexers(indexers); err != nil {
+			return err
+		}
+	}
+	for _, h := range i.handlers {
+		if h.resyncPeriod != nil {
+			informer.AddEventHandlerWithResyncPeriod(h.handler, *h.resyncPeriod)
+		} else {
+			informer.AddEventHandler(h.handler)
+		}
+	}
+	i.namespaceToInformer[namespace] = informer
+	return nil
+}
+
+func (i *multiNamespaceInformer) removeNamespace(namespace string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.namespaceToInformer, namespace)
+}
+
 // AddEventHandler adds the handler to each namespaced informer
 func (i *multiNamespaceInformer) AddEventHandler(handler toolscache.ResourceEventHandler) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.handlers = append(i.handlers, eventHandlerWithResync{handler: handler})
 	for _, informer := range i.namespaceToInformer {
 		informer.AddEventHandler(handler)
 	}
@@ -195,6 +623,9 @@ func (i *multiNamespaceInformer) AddEventHandler(handler toolscache.ResourceEven
 
 // AddEventHandlerWithResyncPeriod adds the handler with a resync period to each namespaced informer
 func (i *multiNamespaceInformer) AddEventHandlerWithResyncPeriod(handler toolscache.ResourceEventHandler, resyncPeriod time.Duration) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.handlers = append(i.handlers, eventHandlerWithResync{handler: handler, resyncPeriod: &resyncPeriod})
 	for _, informer := range i.namespaceToInformer {
 		informer.AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
 	}
@@ -202,17 +633,22 @@ func (i *multiNamespaceInformer) AddEventHandlerWithResyncPeriod(handler toolsca
 
 // AddIndexers adds the indexer for each namespaced informer
 func (i *multiNamespaceInformer) AddIndexers(indexers toolscache.Indexers) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
 	for _, informer := range i.namespaceToInformer {
 		err := informer.AddIndexers(indexers)
 		if err != nil {
 			return err
 		}
 	}
+	i.indexers = append(i.indexers, indexers)
 	return nil
 }
 
 // HasSynced checks if each namespaced informer has synced
 func (i *multiNamespaceInformer) HasSynced() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
 	for _, informer := range i.namespaceToInformer {
 		if ok := informer.HasSynced(); !ok {
 			return ok
@@ -220,3 +656,108 @@ func (i *multiNamespaceInformer) HasSynced() bool {
 	}
 	return true
 }
+
+// namespaceSelectorHandler adds the namespaces matching a label selector to a
+// multi-namespace cache, and removes the others.
+type namespaceSelectorHandler struct {
+	cache    MultiNamespaceCache
+	selector labels.Selector
+
+	mu sync.Mutex
+	// processed are the names of the namespaces the handler added or removed
+	// so far. Deleted namespaces are kept, so that waiting for a namespace
+	// deleted after it was listed doesn't block.
+	processed map[string]struct{}
+	// changed is closed and replaced whenever a namespace is processed.
+	changed chan struct{}
+}
+
+func newNamespaceSelectorHandler(cache MultiNamespaceCache, selector labels.Selector) *namespaceSelectorHandler {
+	return &namespaceSelectorHandler{
+		cache:     cache,
+		selector:  selector,
+		processed: map[string]struct{}{},
+		changed:   make(chan struct{}),
+	}
+}
+
+// markProcessed records that the given namespace was processed.
+func (h *namespaceSelectorHandler) markProcessed(namespace string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.processed[namespace] = struct{}{}
+	close(h.changed)
+	h.changed = make(chan struct{})
+}
+
+// waitForProcessed waits until the given namespaces have all been processed,
+// and returns false if ctx is done first.
+func (h *namespaceSelectorHandler) waitForProcessed(ctx context.Context, namespaces []string) bool {
+	for {
+		h.mu.Lock()
+		missing := false
+		for _, ns := range namespaces {
+			if _, ok := h.processed[ns]; !ok {
+				missing = true
+				break
+			}
+		}
+		changed := h.changed
+		h.mu.Unlock()
+		if !missing {
+			return true
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+var _ toolscache.ResourceEventHandler = &namespaceSelectorHandler{}
+
+func (h *namespaceSelectorHandler) sync(obj interface{}) {
+	ns, ok := obj.(*corev1.Namespace)
+	if !ok {
+		log.Error(nil, "namespace selector got an unexpected object", "type", fmt.Sprintf("%T", obj))
+		return
+	}
+	var err error
+	if h.selector.Matches(labels.Set(ns.Labels)) {
+		err = h.cache.AddNamespace(ns.Name)
+	} else {
+		err = h.cache.RemoveNamespace(ns.Name)
+	}
+	if err != nil {
+		log.Error(err, "multinamespace cache failed to update its namespaces", "namespace", ns.Name)
+	}
+	h.markProcessed(ns.Name)
+}
+
+// OnAdd implements toolscache.ResourceEventHandler
+func (h *namespaceSelectorHandler) OnAdd(obj interface{}) {
+	h.sync(obj)
+}
+
+// OnUpdate implements toolscache.ResourceEventHandler
+func (h *namespaceSelectorHandler) OnUpdate(_, newObj interface{}) {
+	h.sync(newObj)
+}
+
+// OnDelete implements toolscache.ResourceEventHandler
+func (h *namespaceSelectorHandler) OnDelete(obj interface{}) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	ns, ok := obj.(*corev1.Namespace)
+	if !ok {
+		log.Error(nil, "namespace selector got an unexpected object", "type", fmt.Sprintf("%T", obj))
+		return
+	}
+	if err := h.cache.RemoveNamespace(ns.Name); err != nil {
+		log.Error(err, "multinamespace cache failed to update its namespaces", "namespace", ns.Name)
+	}
+	h.markProcessed(ns.Name)
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	kcorev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kcache "k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Multi-Namespace Informer Cache with dynamic namespaces", func() {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		cl     client.Client
+		pods   []client.Object
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		var err error
+		cl, err = client.New(cfg, client.Options{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ensureNode(testNodeOne, cl)).To(Succeed())
+		Expect(ensureNamespace(testNamespaceOne, cl)).To(Succeed())
+		Expect(ensureNamespace(testNamespaceTwo, cl)).To(Succeed())
+		pods = []client.Object{
+			createPod("test-pod-1", testNamespaceOne, kcorev1.RestartPolicyNever),
+			createPod("test-pod-2", testNamespaceTwo, kcorev1.RestartPolicyAlways),
+		}
+	})
+
+	AfterEach(func() {
+		for _, pod := range pods {
+			deletePod(pod)
+		}
+		cancel()
+	})
+
+	startCache := func(c cache.Cache) {
+		go func() {
+			defer GinkgoRecover()
+			Expect(c.Start(ctx)).To(Succeed())
+		}()
+		Expect(c.WaitForCacheSync(ctx)).To(BeTrue())
+	}
+
+	It("should read cluster-scoped objects from a cluster-wide informer", func() {
+		c, err := cache.MultiNamespacedCacheBuilder([]string{testNamespaceOne})(cfg, cache.Options{})
+		Expect(err).NotTo(HaveOccurred())
+		startCache(c)
+
+		By("getting a node")
+		node := &kcorev1.Node{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: testNodeOne}, node)).To(Succeed())
+
+		By("listing cluster roles")
+		roles := &rbacv1.ClusterRoleList{}
+		Expect(c.List(ctx, roles)).To(Succeed())
+		Expect(roles.Items).NotTo(BeEmpty())
+	})
+
+	It("should add and remove namespaces at runtime", func() {
+		c, err := cache.MultiNamespacedCacheBuilder([]string{testNamespaceOne})(cfg, cache.Options{})
+		Expect(err).NotTo(HaveOccurred())
+		mnc, ok := c.(cache.MultiNamespaceCache)
+		Expect(ok).To(BeTrue())
+
+		By("adding an event handler before starting the cache")
+		informer, err := c.GetInformer(ctx, &kcorev1.Pod{})
+		Expect(err).NotTo(HaveOccurred())
+		added := make(chan string, 10)
+		informer.AddEventHandler(kcache.ResourceEventHandlerFuncs{AddFunc: func(obj interface{}) {
+			added <- obj.(*kcorev1.Pod).Name
+		}})
+		startCache(c)
+		Eventually(added).Should(Receive(Equal("test-pod-1")))
+
+		By("verifying the second namespace isn't cached")
+		out := &kcorev1.PodList{}
+		Expect(c.List(ctx, out)).To(Succeed())
+		Expect(out.Items).To(HaveLen(1))
+
+		By("adding the second namespace")
+		Expect(mnc.AddNamespace(testNamespaceTwo)).To(Succeed())
+		Expect(mnc.Namespaces()).To(ConsistOf(testNamespaceOne, testNamespaceTwo))
+		Eventually(added).Should(Receive(Equal("test-pod-2")))
+		Eventually(func() ([]kcorev1.Pod, error) {
+			out := &kcorev1.PodList{}
+			err := c.List(ctx, out)
+			return out.Items, err
+		}).Should(HaveLen(2))
+		pod := &kcorev1.Pod{}
+		Eventually(func() error {
+			return c.Get(ctx, client.ObjectKey{Namespace: testNamespaceTwo, Name: "test-pod-2"}, pod)
+		}).Should(Succeed())
+
+		By("removing the first namespace")
+		Expect(mnc.RemoveNamespace(testNamespaceOne)).To(Succeed())
+		Expect(mnc.Namespaces()).To(ConsistOf(testNamespaceTwo))
+		out = &kcorev1.PodList{}
+		Expect(c.List(ctx, out)).To(Succeed())
+		Expect(out.Items).To(HaveLen(1))
+		Expect(out.Items[0].Namespace).To(Equal(testNamespaceTwo))
+		err = c.Get(ctx, client.ObjectKey{Namespace: testNamespaceOne, Name: "test-pod-1"}, pod)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should follow the namespaces matching a label selector", func() {
+		selected := labels.Set{"cache-test": "selected"}
+		c, err := cache.MultiNamespacedCacheBuilderWithSelector(labels.SelectorFromSet(selected))(cfg, cache.Options{})
+		Expect(err).NotTo(HaveOccurred())
+		mnc := c.(cache.MultiNamespaceCache)
+		startCache(c)
+		Expect(mnc.Namespaces()).NotTo(ContainElement(testNamespaceOne))
+
+		By("labeling a namespace")
+		ns := &kcorev1.Namespace{}
+		Expect(cl.Get(ctx, client.ObjectKey{Name: testNamespaceOne}, ns)).To(Succeed())
+		patch := client.MergeFrom(ns.DeepCopy())
+		ns.Labels = selected
+		Expect(cl.Patch(ctx, ns, patch)).To(Succeed())
+		Eventually(mnc.Namespaces).Should(ContainElement(testNamespaceOne))
+		Eventually(func() ([]kcorev1.Pod, error) {
+			out := &kcorev1.PodList{}
+			err := c.List(ctx, out)
+			return out.Items, err
+		}).Should(HaveLen(1))
+
+		By("removing the label")
+		patch = client.MergeFrom(ns.DeepCopy())
+		ns.Labels = nil
+		Expect(cl.Patch(ctx, ns, patch)).To(Succeed())
+		Eventually(mnc.Namespaces).ShouldNot(ContainElement(testNamespaceOne))
+
+		By("verifying other namespaces are not cached")
+		out := &kmetav1.PartialObjectMetadataList{}
+		out.SetGroupVersionKind(kcorev1.SchemeGroupVersion.WithKind("PodList"))
+		Expect(c.List(ctx, out)).To(Succeed())
+		Expect(out.Items).To(BeEmpty())
+	})
+
+	It("should have synced the namespaces matching a label selector once WaitForCacheSync returns", func() {
+		selected := labels.Set{"cache-sync-test": "selected"}
+		ns := &kcorev1.Namespace{}
+		Expect(cl.Get(ctx, client.ObjectKey{Name: testNamespaceTwo}, ns)).To(Succeed())
+		patch := client.MergeFrom(ns.DeepCopy())
+		ns.Labels = selected
+		Expect(cl.Patch(ctx, ns, patch)).To(Succeed())
+		defer func() {
+			patch := client.MergeFrom(ns.DeepCopy())
+			ns.Labels = nil
+			Expect(cl.Patch(context.Background(), ns, patch)).To(Succeed())
+		}()
+
+		c, err := cache.MultiNamespacedCacheBuilderWithSelector(labels.SelectorFromSet(selected))(cfg, cache.Options{})
+		Expect(err).NotTo(HaveOccurred())
+		startCache(c)
+
+		Expect(c.(cache.MultiNamespaceCache).Namespaces()).To(ConsistOf(testNamespaceTwo))
+		out := &kcorev1.PodList{}
+		Expect(c.List(ctx, out, client.InNamespace(testNamespaceTwo))).To(Succeed())
+		Expect(out.Items).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+var _ = Describe("namespaceSelectorHandler", func() {
+	var c *multiNamespaceCache
+	var h *namespaceSelectorHandler
+
+	BeforeEach(func() {
+		c = &multiNamespaceCache{
+			Scheme:            scheme.Scheme,
+			config:            &rest.Config{},
+			opts:              Options{Scheme: scheme.Scheme, Mapper: meta.NewDefaultRESTMapper(nil)},
+			namespaceToCache:  map[string]Cache{},
+			namespaceToCancel: map[string]context.CancelFunc{},
+			informers:         map[informerKey]*multiNamespaceInformer{},
+		}
+		h = newNamespaceSelectorHandler(c, labels.SelectorFromSet(labels.Set{"selected": "true"}))
+	})
+
+	namespace := func(name string, lbls map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: lbls}}
+	}
+
+	It("should wait until the given namespaces have been processed", func() {
+		waited := make(chan bool)
+		go func() {
+			waited <- h.waitForProcessed(context.Background(), []string{"ns1", "ns2"})
+		}()
+
+		h.OnAdd(namespace("ns1", map[string]string{"selected": "true"}))
+		Consistently(waited, 100*time.Millisecond).ShouldNot(Receive())
+
+		h.OnAdd(namespace("ns2", nil))
+		Eventually(waited).Should(Receive(BeTrue()))
+		Expect(c.Namespaces()).To(ConsistOf("ns1"))
+	})
+
+	It("should not wait for namespaces deleted after they were listed", func() {
+		h.OnAdd(namespace("ns1", map[string]string{"selected": "true"}))
+		h.OnDelete(namespace("ns1", map[string]string{"selected": "true"}))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		Expect(h.waitForProcessed(ctx, []string{"ns1"})).To(BeTrue())
+		Expect(c.Namespaces()).To(BeEmpty())
+	})
+
+	It("should stop waiting when the context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		Expect(h.waitForProcessed(ctx, []string{"ns1"})).To(BeFalse())
+	})
+})
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// ErrFieldManagerRequired is returned by Apply when no field manager has been
+// set with FieldOwner.
+var ErrFieldManagerRequired = errors.New("a field manager must be set for apply requests, use client.FieldOwner")
+
+// ApplyConfigurationToUnstructured converts the given apply configuration to an
+// unstructured object.  If obj is a runtime.Object without type information,
+// its GroupVersionKind is looked up in the given scheme (which may be nil).
+func ApplyConfigurationToUnstructured(obj ApplyConfiguration, scheme *runtime.Scheme) (*unstructured.Unstructured, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u, nil
+	}
+	if obj == nil || reflect.ValueOf(obj).Kind() != reflect.Ptr || reflect.ValueOf(obj).IsNil() {
+		return nil, fmt.Errorf("apply configuration must be a non-nil pointer, got %T", obj)
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	if err := json.Unmarshal(data, &u.Object); err != nil {
+		return nil, err
+	}
+	ro, isObject := obj.(runtime.Object)
+	if isObject {
+		// Typed objects serialize some of their unset fields too, e.g. a null creationTimestamp or
+		// an empty status, which the field manager would then own.
+		removeUnsetFields(u.Object)
+	}
+
+	if isObject && scheme != nil && u.GetKind() == "" {
+		gvk, err := apiutil.GVKForObject(ro, scheme)
+		if err != nil {
+			return nil, err
+		}
+		u.SetGroupVersionKind(gvk)
+	}
+	if u.GetAPIVersion() == "" || u.GetKind() == "" {
+		return nil, fmt.Errorf("apply configuration %T must set apiVersion and kind", obj)
+	}
+	if u.GetName() == "" {
+		return nil, fmt.Errorf("apply configuration %T must set metadata.name", obj)
+	}
+	return u, nil
+}
+
+// unsetFields are the paths of the fields which typed objects serialize even when they are unset.
+var unsetFields = [][]string{
+	{"metadata", "creationTimestamp"},
+	{"spec", "template", "metadata", "creationTimestamp"},
+	{"status"},
+}
+
+// removeUnsetFields removes the unsetFields of obj which are null or empty objects.
+func removeUnsetFields(obj map[string]interface{}) {
+	for _, path := range unsetFields {
+		value, found, err := unstructured.NestedFieldNoCopy(obj, path...)
+		if !found || err != nil {
+			continue
+		}
+		if m, isMap := value.(map[string]interface{}); value == nil || (isMap && len(m) == 0) {
+			unstructured.RemoveNestedField(obj, path...)
+		}
+	}
+}
+
+// decodeApplyResult resets obj and decodes the object returned by the server
+// into it, so that fields that were not part of the response don't linger.
+func decodeApplyResult(data []byte, obj ApplyConfiguration) error {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("apply configuration must be a non-nil pointer, got %T", obj)
+	}
+	v.Elem().Set(reflect.Zero(v.Elem().Type()))
+	return json.Unmarshal(data, obj)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ApplyConfigurationToUnstructured", func() {
+	emptyDirVolume := map[string]interface{}{"name": "data", "emptyDir": map[string]interface{}{}}
+
+	It("should remove the unset fields of typed objects", func() {
+		dep := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "dep", Namespace: "ns"},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{{
+						Name:         "data",
+						VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+					}},
+				}},
+			},
+		}
+		u, err := client.ApplyConfigurationToUnstructured(dep, scheme.Scheme)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(u.GetAPIVersion()).To(Equal("apps/v1"))
+		Expect(u.GetKind()).To(Equal("Deployment"))
+		Expect(u.Object["metadata"]).To(Equal(map[string]interface{}{"name": "dep", "namespace": "ns"}))
+		Expect(u.Object).NotTo(HaveKey("status"))
+		_, found, err := unstructured.NestedFieldNoCopy(u.Object, "spec", "template", "metadata", "creationTimestamp")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		By("keeping the empty objects of list items")
+		volumes, _, err := unstructured.NestedSlice(u.Object, "spec", "template", "spec", "volumes")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(volumes).To(Equal([]interface{}{emptyDirVolume}))
+	})
+
+	It("should keep the empty objects of apply configurations", func() {
+		pod := corev1ac.Pod("pod", "ns").WithSpec(corev1ac.PodSpec().WithVolumes(
+			corev1ac.Volume().WithName("data").WithEmptyDir(corev1ac.EmptyDirVolumeSource()),
+		))
+		u, err := client.ApplyConfigurationToUnstructured(pod, scheme.Scheme)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(u.Object).To(Equal(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata":   map[string]interface{}{"name": "pod", "namespace": "ns"},
+			"spec":       map[string]interface{}{"volumes": []interface{}{emptyDirVolume}},
+		}))
+	})
+})
@@ -25,16 +25,22 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	appsv1ac "k8s.io/client-go/applyconfigurations/apps/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
 	kscheme "k8s.io/client-go/kubernetes/scheme"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 const serverSideTimeoutSeconds = 10
@@ -1064,6 +1070,276 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("Apply", func() {
+		Context("with apply configurations", func() {
+			It("should create the object and return the merged result", func(done Done) {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cl).NotTo(BeNil())
+
+				By("applying a ConfigMap that does not exist yet")
+				name := fmt.Sprintf("apply-cm-%v", count)
+				applyConfig := corev1ac.ConfigMap(name, ns).WithData(map[string]string{"foo": "bar"})
+				err = cl.Apply(context.TODO(), applyConfig, client.FieldOwner("test-owner"))
+				Expect(err).NotTo(HaveOccurred())
+
+				By("validating the merged result was written back")
+				Expect(applyConfig.UID).NotTo(BeNil())
+				Expect(applyConfig.ResourceVersion).NotTo(BeNil())
+
+				By("validating the ConfigMap exists on the server")
+				actual, err := clientset.CoreV1().ConfigMaps(ns).Get(ctx, name, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actual.Data).To(HaveKeyWithValue("foo", "bar"))
+
+				Expect(clientset.CoreV1().ConfigMaps(ns).Delete(ctx, name, metav1.DeleteOptions{})).To(Succeed())
+
+				close(done)
+			})
+
+			It("should fail without a field manager", func() {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+
+				applyConfig := corev1ac.ConfigMap("no-field-manager", ns)
+				Expect(cl.Apply(context.TODO(), applyConfig)).To(MatchError(client.ErrFieldManagerRequired))
+			})
+
+			It("should fail if the apply configuration has no name", func() {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+
+				applyConfig := corev1ac.ConfigMap("", ns)
+				Expect(cl.Apply(context.TODO(), applyConfig, client.FieldOwner("test-owner"))).NotTo(Succeed())
+			})
+
+			It("should report conflicts with other field managers unless ownership is forced", func(done Done) {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("initially creating a Deployment")
+				dep, err := clientset.AppsV1().Deployments(ns).Create(ctx, dep, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("applying a conflicting replica count")
+				applyConfig := appsv1ac.Deployment(dep.Name, ns).
+					WithSpec(appsv1ac.DeploymentSpec().WithReplicas(5))
+				err = cl.Apply(context.TODO(), applyConfig, client.FieldOwner("test-owner"))
+				Expect(apierrors.IsConflict(err)).To(BeTrue())
+
+				By("forcing ownership of the replica count")
+				err = cl.Apply(context.TODO(), applyConfig, client.FieldOwner("test-owner"), client.ForceOwnership)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*applyConfig.Spec.Replicas).To(BeEquivalentTo(5))
+
+				actual, err := clientset.AppsV1().Deployments(ns).Get(ctx, dep.Name, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*actual.Spec.Replicas).To(BeEquivalentTo(5))
+
+				close(done)
+			})
+		})
+
+		Context("with unstructured objects", func() {
+			It("should apply an existing object and preserve type information", func(done Done) {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("initially creating a Deployment")
+				dep, err := clientset.AppsV1().Deployments(ns).Create(ctx, dep, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("applying new annotations")
+				u := &unstructured.Unstructured{}
+				u.SetGroupVersionKind(depGvk)
+				u.SetName(dep.Name)
+				u.SetNamespace(ns)
+				u.SetAnnotations(map[string]string{"foo": "bar"})
+				err = cl.Apply(context.TODO(), u, client.FieldOwner("test-owner"))
+				Expect(err).NotTo(HaveOccurred())
+
+				By("validating the applied Deployment has type information and new annotations")
+				Expect(u.GroupVersionKind()).To(Equal(depGvk))
+				Expect(u.GetAnnotations()).To(HaveKeyWithValue("foo", "bar"))
+				Expect(u.GetUID()).To(Equal(dep.UID))
+
+				close(done)
+			})
+		})
+
+		Context("with metadata objects", func() {
+			It("should apply metadata and preserve type information", func(done Done) {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("initially creating a Deployment")
+				dep, err := clientset.AppsV1().Deployments(ns).Create(ctx, dep, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("applying new labels")
+				obj := &metav1.PartialObjectMetadata{}
+				obj.SetGroupVersionKind(depGvk)
+				obj.SetName(dep.Name)
+				obj.SetNamespace(ns)
+				obj.SetLabels(map[string]string{"applied": "true"})
+				err = cl.Apply(context.TODO(), obj, client.FieldOwner("test-owner"))
+				Expect(err).NotTo(HaveOccurred())
+
+				By("validating the applied Deployment has type information")
+				Expect(obj.GroupVersionKind()).To(Equal(depGvk))
+
+				actual, err := clientset.AppsV1().Deployments(ns).Get(ctx, dep.Name, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actual.Labels).To(HaveKeyWithValue("applied", "true"))
+
+				close(done)
+			})
+		})
+
+		Context("on the status subresource", func() {
+			It("should apply status", func(done Done) {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("initially creating a Deployment")
+				dep, err := clientset.AppsV1().Deployments(ns).Create(ctx, dep, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("applying the status of the Deployment")
+				applyConfig := appsv1ac.Deployment(dep.Name, ns).
+					WithStatus(appsv1ac.DeploymentStatus().WithReplicas(1))
+				err = cl.Status().Apply(context.TODO(), applyConfig, client.FieldOwner("test-owner"))
+				Expect(err).NotTo(HaveOccurred())
+
+				actual, err := clientset.AppsV1().Deployments(ns).Get(ctx, dep.Name, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actual.Status.Replicas).To(BeEquivalentTo(1))
+
+				close(done)
+			})
+		})
+	})
+
+	Describe("SubResourceClient", func() {
+		Context("with structured objects", func() {
+			It("should be able to read and update the scale subresource", func(done Done) {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cl).NotTo(BeNil())
+
+				By("initially creating a Deployment")
+				dep, err := clientset.AppsV1().Deployments(ns).Create(ctx, dep, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("getting the scale of the Deployment")
+				scale := &autoscalingv1.Scale{}
+				err = cl.SubResource("scale").Get(context.TODO(), dep, scale)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scale.Spec.Replicas).To(Equal(replicaCount))
+
+				By("updating the scale of the Deployment")
+				scale.Spec.Replicas = 5
+				err = cl.SubResource("scale").Update(context.TODO(), dep, client.WithSubResourceBody(scale))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scale.Spec.Replicas).To(BeEquivalentTo(5))
+
+				By("patching the scale of the Deployment")
+				err = cl.SubResource("scale").Patch(context.TODO(), dep,
+					client.RawPatch(types.MergePatchType, []byte(`{"spec":{"replicas":3}}`)),
+					client.WithSubResourceBody(scale))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scale.Spec.Replicas).To(BeEquivalentTo(3))
+
+				actual, err := clientset.AppsV1().Deployments(ns).Get(ctx, dep.Name, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*actual.Spec.Replicas).To(BeEquivalentTo(3))
+
+				close(done)
+			})
+
+			It("should be able to create an eviction for a pod", func(done Done) {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("initially creating a Pod")
+				pod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("evicted-pod-%v", count), Namespace: ns},
+					Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}}},
+				}
+				pod, err = clientset.CoreV1().Pods(ns).Create(ctx, pod, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("evicting the Pod")
+				eviction := &policyv1beta1.Eviction{
+					ObjectMeta:    metav1.ObjectMeta{Name: pod.Name, Namespace: ns},
+					DeleteOptions: &metav1.DeleteOptions{GracePeriodSeconds: new(int64)},
+				}
+				err = cl.SubResource("eviction").Create(context.TODO(), pod, eviction)
+				Expect(err).NotTo(HaveOccurred())
+
+				close(done)
+			})
+
+			It("should be able to request a token for a service account", func(done Done) {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("initially creating a ServiceAccount")
+				sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("sa-%v", count), Namespace: ns}}
+				sa, err = clientset.CoreV1().ServiceAccounts(ns).Create(ctx, sa, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("requesting a token")
+				tokenRequest := &authenticationv1.TokenRequest{}
+				err = cl.SubResource("token").Create(context.TODO(), sa, tokenRequest)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tokenRequest.Status.Token).NotTo(BeEmpty())
+
+				Expect(clientset.CoreV1().ServiceAccounts(ns).Delete(ctx, sa.Name, metav1.DeleteOptions{})).To(Succeed())
+
+				close(done)
+			})
+		})
+
+		Context("with unstructured objects", func() {
+			It("should be able to read the scale subresource", func(done Done) {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("initially creating a Deployment")
+				dep, err := clientset.AppsV1().Deployments(ns).Create(ctx, dep, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				By("getting the scale of the Deployment")
+				u := &unstructured.Unstructured{}
+				u.SetGroupVersionKind(depGvk)
+				u.SetName(dep.Name)
+				u.SetNamespace(ns)
+				scale := &unstructured.Unstructured{}
+				err = cl.SubResource("scale").Get(context.TODO(), u, scale)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scale.GetKind()).To(Equal("Scale"))
+
+				replicas, found, err := unstructured.NestedInt64(scale.Object, "spec", "replicas")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(replicas).To(BeEquivalentTo(replicaCount))
+
+				close(done)
+			})
+		})
+
+		Context("with metadata objects", func() {
+			It("should fail with an error", func() {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+
+				obj := metaOnlyFromObj(dep, scheme)
+				Expect(cl.SubResource("scale").Get(context.TODO(), obj, &autoscalingv1.Scale{})).NotTo(Succeed())
+			})
+		})
+	})
+
 	Describe("Delete", func() {
 		Context("with structured objects", func() {
 			It("should delete an existing object from a go struct", func(done Done) {
@@ -3237,6 +3513,137 @@ var _ = Describe("DelegatingClient", func() {
 			})
 		})
 	})
+	Describe("ReadYourWrites", func() {
+		var (
+			apiClient client.Client
+			cache     *laggingReader
+			cm        *corev1.ConfigMap
+		)
+
+		BeforeEach(func() {
+			apiClient = fake.NewClientBuilder().Build()
+			cache = &laggingReader{Reader: apiClient}
+			cm = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "read-your-writes"}}
+		})
+
+		catchUpLater := func() {
+			go func() {
+				defer GinkgoRecover()
+				time.Sleep(50 * time.Millisecond)
+				atomic.StoreInt32(&cache.caughtUp, 1)
+			}()
+		}
+
+		It("should not wait for the cache by default", func() {
+			cl, err := client.NewDelegatingClient(client.NewDelegatingClientInput{
+				CacheReader: cache,
+				Client:      apiClient,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cl.Create(context.Background(), cm)).To(Succeed())
+
+			err = cl.Get(context.Background(), client.ObjectKeyFromObject(cm), &corev1.ConfigMap{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should wait for the cache to observe a write before reading from it", func() {
+			cl, err := client.NewDelegatingClient(client.NewDelegatingClientInput{
+				CacheReader:           cache,
+				Client:                apiClient,
+				ReadYourWrites:        true,
+				ReadYourWritesTimeout: time.Minute,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cl.Create(context.Background(), cm)).To(Succeed())
+			catchUpLater()
+
+			actual := &corev1.ConfigMap{}
+			Expect(cl.Get(context.Background(), client.ObjectKeyFromObject(cm), actual)).To(Succeed())
+			Expect(actual.ResourceVersion).To(Equal(cm.ResourceVersion))
+			Expect(atomic.LoadInt32(&cache.calls)).To(BeNumerically(">", 1))
+		})
+
+		It("should fall back to the API server if the cache doesn't observe a write in time", func() {
+			cl, err := client.NewDelegatingClient(client.NewDelegatingClientInput{
+				CacheReader:           cache,
+				Client:                apiClient,
+				ReadYourWrites:        true,
+				ReadYourWritesTimeout: 50 * time.Millisecond,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cl.Create(context.Background(), cm)).To(Succeed())
+
+			actual := &corev1.ConfigMap{}
+			Expect(cl.Get(context.Background(), client.ObjectKeyFromObject(cm), actual)).To(Succeed())
+			Expect(actual.ResourceVersion).To(Equal(cm.ResourceVersion))
+
+			By("not waiting again for the same write")
+			err = cl.Get(context.Background(), client.ObjectKeyFromObject(cm), actual)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should wait for the cache to observe status writes", func() {
+			Expect(apiClient.Create(context.Background(), cm)).To(Succeed())
+			atomic.StoreInt32(&cache.caughtUp, 1)
+			cl, err := client.NewDelegatingClient(client.NewDelegatingClientInput{
+				CacheReader:           cache,
+				Client:                apiClient,
+				ReadYourWrites:        true,
+				ReadYourWritesTimeout: time.Minute,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			atomic.StoreInt32(&cache.caughtUp, 0)
+			cm.Data = map[string]string{"foo": "bar"}
+			Expect(cl.Status().Update(context.Background(), cm)).To(Succeed())
+			catchUpLater()
+
+			actual := &corev1.ConfigMap{}
+			Expect(cl.Get(context.Background(), client.ObjectKeyFromObject(cm), actual)).To(Succeed())
+			Expect(actual.ResourceVersion).To(Equal(cm.ResourceVersion))
+		})
+
+		It("should wait for the cache to observe writes to listed objects", func() {
+			cl, err := client.NewDelegatingClient(client.NewDelegatingClientInput{
+				CacheReader:           cache,
+				Client:                apiClient,
+				ReadYourWrites:        true,
+				ReadYourWritesTimeout: time.Minute,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cl.Create(context.Background(), cm)).To(Succeed())
+			catchUpLater()
+
+			actual := &corev1.ConfigMapList{}
+			Expect(cl.List(context.Background(), actual, client.InNamespace("default"))).To(Succeed())
+			Expect(actual.Items).To(HaveLen(1))
+			Expect(actual.Items[0].Name).To(Equal(cm.Name))
+		})
+
+		It("should wait for the cache to observe a deletion by key", func() {
+			cm.UID = "read-your-writes-uid"
+			Expect(apiClient.Create(context.Background(), cm.DeepCopy())).To(Succeed())
+			stale := fake.NewClientBuilder().WithObjects(cm.DeepCopy()).Build()
+			cl, err := client.NewDelegatingClient(client.NewDelegatingClientInput{
+				CacheReader:           stale,
+				Client:                apiClient,
+				ReadYourWrites:        true,
+				ReadYourWritesTimeout: time.Minute,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			key := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: cm.Namespace, Name: cm.Name}}
+			Expect(cl.Delete(context.Background(), key)).To(Succeed())
+			go func() {
+				defer GinkgoRecover()
+				time.Sleep(50 * time.Millisecond)
+				Expect(stale.Delete(context.Background(), cm.DeepCopy())).To(Succeed())
+			}()
+
+			err = cl.Get(context.Background(), client.ObjectKeyFromObject(cm), &corev1.ConfigMap{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
 })
 
 var _ = Describe("Patch", func() {
@@ -3409,11 +3816,35 @@ var _ = Describe("IgnoreNotFound", func() {
 	})
 })
 
+// laggingReader is a cache reader that doesn't contain any objects until it
+// has caught up, after which it reads from the wrapped reader.
+type laggingReader struct {
+	client.Reader
+	caughtUp int32
+	calls    int32
+}
+
+func (l *laggingReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	atomic.AddInt32(&l.calls, 1)
+	if atomic.LoadInt32(&l.caughtUp) == 0 {
+		return apierrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+	return l.Reader.Get(ctx, key, obj, opts...)
+}
+
+func (l *laggingReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	atomic.AddInt32(&l.calls, 1)
+	if atomic.LoadInt32(&l.caughtUp) == 0 {
+		return nil
+	}
+	return l.Reader.List(ctx, list, opts...)
+}
+
 type fakeReader struct {
 	Called int
 }
 
-func (f *fakeReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+func (f *fakeReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
 	f.Called = f.Called + 1
 	return nil
 }
@@ -71,9 +71,14 @@ func (c *dryRunClient) Patch(ctx context.Context, obj Object, patch Patch, opts
 	return c.client.Patch(ctx, obj, patch, append(opts, DryRunAll)...)
 }
 
+// Apply implements client.Client
+func (c *dryRunClient) Apply(ctx context.Context, obj ApplyConfiguration, opts ...ApplyOption) error {
+	return c.client.Apply(ctx, obj, append(opts, DryRunAll)...)
+}
+
 // Get implements client.Client
-func (c *dryRunClient) Get(ctx context.Context, key ObjectKey, obj Object) error {
-	return c.client.Get(ctx, key, obj)
+func (c *dryRunClient) Get(ctx context.Context, key ObjectKey, obj Object, opts ...GetOption) error {
+	return c.client.Get(ctx, key, obj, opts...)
 }
 
 // List implements client.Client
@@ -104,3 +109,42 @@ func (sw *dryRunStatusWriter) Update(ctx context.Context, obj Object, opts ...Up
 func (sw *dryRunStatusWriter) Patch(ctx context.Context, obj Object, patch Patch, opts ...PatchOption) error {
 	return sw.client.Patch(ctx, obj, patch, append(opts, DryRunAll)...)
 }
+
+// Apply implements client.StatusWriter
+func (sw *dryRunStatusWriter) Apply(ctx context.Context, obj ApplyConfiguration, opts ...ApplyOption) error {
+	return sw.client.Apply(ctx, obj, append(opts, DryRunAll)...)
+}
+
+// SubResource implements client.SubResourceClientConstructor
+func (c *dryRunClient) SubResource(subResource string) SubResourceClient {
+	return &dryRunSubResourceClient{client: c.client.SubResource(subResource)}
+}
+
+// ensure dryRunSubResourceClient implements client.SubResourceClient
+var _ SubResourceClient = &dryRunSubResourceClient{}
+
+// dryRunSubResourceClient is client.SubResourceClient that writes a subresource with dryRun mode
+// enforced.
+type dryRunSubResourceClient struct {
+	client SubResourceClient
+}
+
+// Get implements client.SubResourceClient
+func (sc *dryRunSubResourceClient) Get(ctx context.Context, obj Object, subResource Object) error {
+	return sc.client.Get(ctx, obj, subResource)
+}
+
+// Create implements client.SubResourceClient
+func (sc *dryRunSubResourceClient) Create(ctx context.Context, obj Object, subResource Object, opts ...SubResourceCreateOption) error {
+	return sc.client.Create(ctx, obj, subResource, append(opts, DryRunAll)...)
+}
+
+// Update implements client.SubResourceClient
+func (sc *dryRunSubResourceClient) Update(ctx context.Context, obj Object, opts ...SubResourceUpdateOption) error {
+	return sc.client.Update(ctx, obj, append(opts, DryRunAll)...)
+}
+
+// Patch implements client.SubResourceClient
+func (sc *dryRunSubResourceClient) Patch(ctx context.Context, obj Object, patch Patch, opts ...SubResourcePatchOption) error {
+	return sc.client.Patch(ctx, obj, patch, append(opts, DryRunAll)...)
+}
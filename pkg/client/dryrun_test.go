@@ -24,10 +24,12 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	appsv1ac "k8s.io/client-go/applyconfigurations/apps/v1"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -261,4 +263,31 @@ var _ = Describe("DryRunClient", func() {
 		Expect(actual).NotTo(BeNil())
 		Expect(actual).To(BeEquivalentTo(dep))
 	})
+
+	It("should not change objects via apply", func() {
+		replicas := int32(99)
+		applyConfig := appsv1ac.Deployment(dep.Name, ns).
+			WithSpec(appsv1ac.DeploymentSpec().WithReplicas(replicas))
+
+		Expect(getClient().Apply(ctx, applyConfig, client.FieldOwner("dry-run-test"), client.ForceOwnership)).ToNot(HaveOccurred())
+		Expect(*applyConfig.Spec.Replicas).To(BeEquivalentTo(replicas))
+
+		actual, err := clientset.AppsV1().Deployments(ns).Get(ctx, dep.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual).NotTo(BeNil())
+		Expect(actual).To(BeEquivalentTo(dep))
+	})
+
+	It("should not change objects via subresource update", func() {
+		scale := &autoscalingv1.Scale{}
+		Expect(getClient().SubResource("scale").Get(ctx, dep, scale)).ToNot(HaveOccurred())
+		scale.Spec.Replicas = 99
+
+		Expect(getClient().SubResource("scale").Update(ctx, dep, client.WithSubResourceBody(scale))).ToNot(HaveOccurred())
+
+		actual, err := clientset.AppsV1().Deployments(ns).Get(ctx, dep.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual).NotTo(BeNil())
+		Expect(actual).To(BeEquivalentTo(dep))
+	})
 })
@@ -0,0 +1,566 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/testing"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// fakeCache is a cache.Cache reading from the storage of a fake client.
+// Its informers list and watch the objects of the client's tracker, so that
+// writes made through the client are delivered to their event handlers.
+type fakeCache struct {
+	client *fakeClient
+
+	mu sync.Mutex
+	// informers are keyed by GroupVersionKind and by the type of object
+	// they deliver, since typed, unstructured and metadata-only informers
+	// of the same kind are distinct, like in the informer cache.
+	informers map[informerKey]*fakeInformer
+	// ctx is the context the cache was started with, nil until then.
+	ctx     context.Context
+	started chan struct{}
+}
+
+var _ cache.Cache = &fakeCache{}
+
+type informerKey struct {
+	gvk     schema.GroupVersionKind
+	objType reflect.Type
+}
+
+func newFakeCache(c *fakeClient) *fakeCache {
+	return &fakeCache{
+		client:    c,
+		informers: map[informerKey]*fakeInformer{},
+		started:   make(chan struct{}),
+	}
+}
+
+// Get implements client.Reader.
+func (c *fakeCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.client.Get(ctx, key, obj, opts...)
+}
+
+// List implements client.Reader.
+func (c *fakeCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.client.List(ctx, list, opts...)
+}
+
+// GetInformer implements cache.Informers.
+func (c *fakeCache) GetInformer(ctx context.Context, obj client.Object) (cache.Informer, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.client.scheme)
+	if err != nil {
+		return nil, err
+	}
+	return c.informerFor(ctx, gvk, obj)
+}
+
+// GetInformerForKind implements cache.Informers.
+func (c *fakeCache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind) (cache.Informer, error) {
+	obj, err := c.client.scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	return c.informerFor(ctx, gvk, obj)
+}
+
+// informerFor returns the informer for objects of the given kind and of
+// the type of obj, creating it if needed.  Like in the informer cache, new
+// informers are run right away if the cache has been started, and waited
+// for until they are synced.
+func (c *fakeCache) informerFor(ctx context.Context, gvk schema.GroupVersionKind, obj runtime.Object) (*fakeInformer, error) {
+	key := informerKey{gvk: gvk, objType: reflect.TypeOf(obj)}
+
+	c.mu.Lock()
+	i, exists := c.informers[key]
+	if !exists {
+		i = c.newInformer(gvk, obj)
+		c.informers[key] = i
+		if c.ctx != nil {
+			i.run(c.ctx)
+		}
+	}
+	started := c.ctx != nil
+	c.mu.Unlock()
+
+	if !exists && started && !toolscache.WaitForCacheSync(ctx.Done(), i.HasSynced) {
+		return nil, fmt.Errorf("failed waiting for %v informer to sync", gvk)
+	}
+	return i, nil
+}
+
+// RemoveInformer implements cache.Informers.
+func (c *fakeCache) RemoveInformer(ctx context.Context, obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, c.client.scheme)
+	if err != nil {
+		return err
+	}
+	key := informerKey{gvk: gvk, objType: reflect.TypeOf(obj)}
+
+	c.mu.Lock()
+	i, exists := c.informers[key]
+	delete(c.informers, key)
+	c.mu.Unlock()
+
+	if exists {
+		i.stop()
+	}
+	return nil
+}
+
+// Start implements cache.Informers.  It runs the informers of the cache
+// until ctx is done.
+func (c *fakeCache) Start(ctx context.Context) error {
+	c.mu.Lock()
+	if c.ctx != nil {
+		c.mu.Unlock()
+		return errors.New("fake cache was already started")
+	}
+	c.ctx = ctx
+	for _, i := range c.informers {
+		i.run(ctx)
+	}
+	close(c.started)
+	c.mu.Unlock()
+
+	<-ctx.Done()
+	return nil
+}
+
+// WaitForCacheSync implements cache.Informers.  It waits for the cache to be
+// started and for its informers to be synced.
+func (c *fakeCache) WaitForCacheSync(ctx context.Context) bool {
+	select {
+	case <-c.started:
+	case <-ctx.Done():
+		return false
+	}
+
+	c.mu.Lock()
+	synced := make([]toolscache.InformerSynced, 0, len(c.informers))
+	for _, i := range c.informers {
+		synced = append(synced, i.HasSynced)
+	}
+	c.mu.Unlock()
+	return toolscache.WaitForCacheSync(ctx.Done(), synced...)
+}
+
+// IndexField implements client.FieldIndexer.  The index is used by List
+// requests with field selectors, both on the cache and on the fake client.
+func (c *fakeCache) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	gvk, err := apiutil.GVKForObject(obj, c.client.scheme)
+	if err != nil {
+		return err
+	}
+
+	c.client.indexesLock.Lock()
+	defer c.client.indexesLock.Unlock()
+	if _, exists := c.client.indexes[gvk][field]; exists {
+		return fmt.Errorf("an index with name %q has already been registered for GroupVersionKind %v", field, gvk)
+	}
+	// Copy the indexes of the kind, since readers use them without holding
+	// the lock.
+	indexes := make(map[string]client.IndexerFunc, len(c.client.indexes[gvk])+1)
+	for name, indexer := range c.client.indexes[gvk] {
+		indexes[name] = indexer
+	}
+	indexes[field] = extractValue
+	c.client.indexes[gvk] = indexes
+	return nil
+}
+
+// newInformer returns a new informer listing and watching the objects of the
+// given kind in the tracker, delivering them as objects of the type of obj.
+func (c *fakeCache) newInformer(gvk schema.GroupVersionKind, obj runtime.Object) *fakeInformer {
+	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+	lw := &trackerListWatch{
+		tracker:   c.client.tracker,
+		gvr:       gvr,
+		converter: &objectConverter{scheme: c.client.scheme, gvk: gvk, obj: obj},
+	}
+	informer := toolscache.NewSharedIndexInformer(&toolscache.ListWatch{ListFunc: lw.list, WatchFunc: lw.watch}, obj.DeepCopyObject(), 0, toolscache.Indexers{
+		toolscache.NamespaceIndex: toolscache.MetaNamespaceIndexFunc,
+	})
+	return &fakeInformer{SharedIndexInformer: informer, lw: lw}
+}
+
+// trackerListWatch lists and watches the objects of a resource in the
+// tracker.  The watch is started before listing, so that no write made in
+// between is missed, and handed over to the watch call the informer makes
+// right after listing.
+type trackerListWatch struct {
+	tracker   versionedTracker
+	gvr       schema.GroupVersionResource
+	converter *objectConverter
+
+	mu      sync.Mutex
+	pending watch.Interface
+}
+
+func (lw *trackerListWatch) list(opts metav1.ListOptions) (runtime.Object, error) {
+	w, err := lw.tracker.Watch(lw.gvr, metav1.NamespaceAll)
+	if err != nil {
+		return nil, err
+	}
+	list, err := lw.converter.list(lw.tracker, lw.gvr)
+	if err != nil {
+		w.Stop()
+		return nil, err
+	}
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	if lw.pending != nil {
+		lw.pending.Stop()
+	}
+	lw.pending = w
+	return list, nil
+}
+
+func (lw *trackerListWatch) watch(opts metav1.ListOptions) (watch.Interface, error) {
+	lw.mu.Lock()
+	w := lw.pending
+	lw.pending = nil
+	lw.mu.Unlock()
+	if w == nil {
+		var err error
+		if w, err = lw.tracker.Watch(lw.gvr, metav1.NamespaceAll); err != nil {
+			return nil, err
+		}
+	}
+	return watch.Filter(w, lw.converter.convertEvent), nil
+}
+
+// stop stops the watch started by the last list call, if it wasn't handed
+// over, so that it doesn't keep buffering events nobody consumes.
+func (lw *trackerListWatch) stop() {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	if lw.pending != nil {
+		lw.pending.Stop()
+		lw.pending = nil
+	}
+}
+
+// bufferingTracker is an object tracker whose watches buffer any number of
+// events.  The watches of testing.ObjectTracker are sent their events while
+// the object is written, and panic once 100 events are waiting for their
+// consumer.  Writes are serialized with moving those events to the unbounded
+// buffer of the watches, so the tracker's buffer never holds more than the
+// events of a single write.
+type bufferingTracker struct {
+	testing.ObjectTracker
+
+	mu      sync.Mutex
+	watches map[*bufferedWatch]struct{}
+}
+
+func newBufferingTracker(tracker testing.ObjectTracker) *bufferingTracker {
+	return &bufferingTracker{
+		ObjectTracker: tracker,
+		watches:       map[*bufferedWatch]struct{}{},
+	}
+}
+
+// Add implements testing.ObjectTracker.
+func (t *bufferingTracker) Add(obj runtime.Object) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	defer t.drain()
+	return t.ObjectTracker.Add(obj)
+}
+
+// Create implements testing.ObjectTracker.
+func (t *bufferingTracker) Create(gvr schema.GroupVersionResource, obj runtime.Object, ns string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	defer t.drain()
+	return t.ObjectTracker.Create(gvr, obj, ns)
+}
+
+// Update implements testing.ObjectTracker.
+func (t *bufferingTracker) Update(gvr schema.GroupVersionResource, obj runtime.Object, ns string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	defer t.drain()
+	return t.ObjectTracker.Update(gvr, obj, ns)
+}
+
+// Delete implements testing.ObjectTracker.
+func (t *bufferingTracker) Delete(gvr schema.GroupVersionResource, ns, name string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	defer t.drain()
+	return t.ObjectTracker.Delete(gvr, ns, name)
+}
+
+// Watch implements testing.ObjectTracker.
+func (t *bufferingTracker) Watch(gvr schema.GroupVersionResource, ns string) (watch.Interface, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	w, err := t.ObjectTracker.Watch(gvr, ns)
+	if err != nil {
+		return nil, err
+	}
+	bw := &bufferedWatch{
+		tracker: t,
+		source:  w,
+		result:  make(chan watch.Event),
+		ready:   make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	t.watches[bw] = struct{}{}
+	go bw.forward()
+	return bw, nil
+}
+
+// drain moves the events sent by the last write to the buffers of the
+// watches.  It must be called with the lock held.
+func (t *bufferingTracker) drain() {
+	for w := range t.watches {
+		w.drain()
+	}
+}
+
+// bufferedWatch is a watch of a bufferingTracker.
+type bufferedWatch struct {
+	tracker *bufferingTracker
+	source  watch.Interface
+	result  chan watch.Event
+
+	mu      sync.Mutex
+	pending []watch.Event
+	// ready is signaled when events are added to pending.
+	ready    chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// drain moves the events waiting in the tracker's watch to pending.
+func (w *bufferedWatch) drain() {
+	for {
+		select {
+		case event, ok := <-w.source.ResultChan():
+			if !ok {
+				return
+			}
+			w.mu.Lock()
+			w.pending = append(w.pending, event)
+			w.mu.Unlock()
+			select {
+			case w.ready <- struct{}{}:
+			default:
+			}
+		default:
+			return
+		}
+	}
+}
+
+// forward sends the pending events to the result channel until the watch
+// is stopped.
+func (w *bufferedWatch) forward() {
+	defer close(w.result)
+	for {
+		w.mu.Lock()
+		var event watch.Event
+		hasEvent := len(w.pending) > 0
+		if hasEvent {
+			event = w.pending[0]
+			w.pending = w.pending[1:]
+		}
+		w.mu.Unlock()
+
+		if !hasEvent {
+			select {
+			case <-w.ready:
+				continue
+			case <-w.done:
+				return
+			}
+		}
+		select {
+		case w.result <- event:
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// ResultChan implements watch.Interface.
+func (w *bufferedWatch) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+// Stop implements watch.Interface.
+func (w *bufferedWatch) Stop() {
+	w.stopOnce.Do(func() {
+		w.tracker.mu.Lock()
+		delete(w.tracker.watches, w)
+		w.tracker.mu.Unlock()
+		w.source.Stop()
+		close(w.done)
+	})
+}
+
+// fakeInformer is a shared informer that records its event handlers, so
+// that they can be notified when the informer is removed from the cache.
+type fakeInformer struct {
+	toolscache.SharedIndexInformer
+
+	lw *trackerListWatch
+
+	mu       sync.Mutex
+	handlers []toolscache.ResourceEventHandler
+	cancel   context.CancelFunc
+}
+
+// AddEventHandler implements cache.Informer.
+func (i *fakeInformer) AddEventHandler(handler toolscache.ResourceEventHandler) {
+	i.addHandler(handler)
+	i.SharedIndexInformer.AddEventHandler(handler)
+}
+
+// AddEventHandlerWithResyncPeriod implements cache.Informer.
+func (i *fakeInformer) AddEventHandlerWithResyncPeriod(handler toolscache.ResourceEventHandler, resyncPeriod time.Duration) {
+	i.addHandler(handler)
+	i.SharedIndexInformer.AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+}
+
+func (i *fakeInformer) addHandler(handler toolscache.ResourceEventHandler) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.handlers = append(i.handlers, handler)
+}
+
+// run runs the informer until ctx is done or the informer is stopped.
+func (i *fakeInformer) run(ctx context.Context) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	ctx, i.cancel = context.WithCancel(ctx)
+	go func() {
+		i.SharedIndexInformer.Run(ctx.Done())
+		i.lw.stop()
+	}()
+}
+
+// stop stops the informer and notifies the handlers implementing
+// cache.InformerRemovedHandler.
+func (i *fakeInformer) stop() {
+	i.mu.Lock()
+	handlers := i.handlers
+	if i.cancel != nil {
+		i.cancel()
+	}
+	i.mu.Unlock()
+
+	for _, h := range handlers {
+		if removed, ok := h.(cache.InformerRemovedHandler); ok {
+			removed.OnInformerRemoved()
+		}
+	}
+}
+
+// objectConverter converts the objects stored in the tracker, which are
+// typed for kinds known to the scheme, to the type of objects delivered by
+// an informer: typed, unstructured or metadata-only.
+type objectConverter struct {
+	scheme *runtime.Scheme
+	gvk    schema.GroupVersionKind
+	obj    runtime.Object
+}
+
+func (c *objectConverter) convert(obj runtime.Object) (runtime.Object, error) {
+	var out runtime.Object
+	if reflect.TypeOf(obj) == reflect.TypeOf(c.obj) {
+		out = obj.DeepCopyObject()
+	} else {
+		// Objects stored typed don't have their type information set,
+		// which the serialization needs.
+		in := obj.DeepCopyObject()
+		in.GetObjectKind().SetGroupVersionKind(c.gvk)
+		out = c.obj.DeepCopyObject()
+		if err := copyInto(in, out); err != nil {
+			return nil, err
+		}
+	}
+	out.GetObjectKind().SetGroupVersionKind(c.gvk)
+	return out, nil
+}
+
+// convertEvent converts the object of a watch event.  Events whose object
+// can't be converted are dropped.
+func (c *objectConverter) convertEvent(in watch.Event) (watch.Event, bool) {
+	obj, err := c.convert(in.Object)
+	if err != nil {
+		return in, false
+	}
+	return watch.Event{Type: in.Type, Object: obj}, true
+}
+
+// list returns the objects of the tracker as a list of converted objects.
+func (c *objectConverter) list(tracker versionedTracker, gvr schema.GroupVersionResource) (runtime.Object, error) {
+	stored, err := tracker.List(gvr, c.gvk, metav1.NamespaceAll)
+	if err != nil {
+		return nil, err
+	}
+	items, err := meta.ExtractList(stored)
+	if err != nil {
+		return nil, err
+	}
+	for idx, item := range items {
+		if items[idx], err = c.convert(item); err != nil {
+			return nil, err
+		}
+	}
+
+	listGVK := c.gvk.GroupVersion().WithKind(c.gvk.Kind + "List")
+	var list runtime.Object
+	switch c.obj.(type) {
+	case *unstructured.Unstructured:
+		list = &unstructured.UnstructuredList{}
+	case *metav1.PartialObjectMetadata:
+		list = &metav1.PartialObjectMetadataList{}
+	default:
+		if list, err = c.scheme.New(listGVK); err != nil {
+			return nil, err
+		}
+	}
+	list.GetObjectKind().SetGroupVersionKind(listGVK)
+	if err := meta.SetList(list, items); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// recordingHandler records the names of the objects of the events it gets.
+type recordingHandler struct {
+	mu      sync.Mutex
+	events  []string
+	removed bool
+}
+
+func (h *recordingHandler) record(event string, obj interface{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, event+" "+obj.(metav1.Object).GetName())
+}
+
+func (h *recordingHandler) OnAdd(obj interface{})       { h.record("add", obj) }
+func (h *recordingHandler) OnUpdate(_, obj interface{}) { h.record("update", obj) }
+func (h *recordingHandler) OnDelete(obj interface{})    { h.record("delete", obj) }
+
+func (h *recordingHandler) OnInformerRemoved() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removed = true
+}
+
+func (h *recordingHandler) Events() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.events...)
+}
+
+func (h *recordingHandler) Removed() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.removed
+}
+
+var _ = Describe("Fake cache", func() {
+	var (
+		cl       client.Client
+		c        cache.Cache
+		ctx      context.Context
+		cancel   context.CancelFunc
+		existing *corev1.ConfigMap
+	)
+
+	BeforeEach(func() {
+		existing = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "ns"},
+			Data:       map[string]string{"key": "value"},
+		}
+		cl, c = NewClientBuilder().WithObjects(existing).BuildWithCache()
+		ctx, cancel = context.WithCancel(context.Background())
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	startCache := func() {
+		go func() {
+			defer GinkgoRecover()
+			Expect(c.Start(ctx)).To(Succeed())
+		}()
+		Expect(c.WaitForCacheSync(ctx)).To(BeTrue())
+	}
+
+	It("should read the objects of the client", func() {
+		By("Getting an object created with the client")
+		created := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "created", Namespace: "ns"}}
+		Expect(cl.Create(ctx, created)).To(Succeed())
+		obj := &corev1.ConfigMap{}
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(created), obj)).To(Succeed())
+		Expect(obj.ResourceVersion).To(Equal(created.ResourceVersion))
+
+		By("Listing the objects")
+		list := &corev1.ConfigMapList{}
+		Expect(c.List(ctx, list, client.InNamespace("ns"))).To(Succeed())
+		Expect(list.Items).To(HaveLen(2))
+	})
+
+	It("should deliver events for writes made through the client", func() {
+		informer, err := c.GetInformer(ctx, &corev1.ConfigMap{})
+		Expect(err).NotTo(HaveOccurred())
+		h := &recordingHandler{}
+		informer.AddEventHandler(h)
+		startCache()
+
+		By("Receiving the existing objects")
+		Eventually(h.Events).Should(Equal([]string{"add existing"}))
+
+		By("Creating, updating and deleting an object")
+		obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "other-ns"}}
+		Expect(cl.Create(ctx, obj)).To(Succeed())
+		obj.Data = map[string]string{"key": "value"}
+		Expect(cl.Update(ctx, obj)).To(Succeed())
+		Expect(cl.Delete(ctx, obj)).To(Succeed())
+		Eventually(h.Events).Should(Equal([]string{"add existing", "add new", "update new", "delete new"}))
+	})
+
+	It("should buffer the events of more than 100 writes", func() {
+		const writes = 150
+
+		By("Writing while a watch isn't consumed")
+		w, err := cl.(*fakeClient).tracker.Watch(corev1.SchemeGroupVersion.WithResource("configmaps"), metav1.NamespaceAll)
+		Expect(err).NotTo(HaveOccurred())
+		defer w.Stop()
+		for i := 0; i < writes; i++ {
+			obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("cm-%d", i), Namespace: "ns"}}
+			Expect(cl.Create(ctx, obj)).To(Succeed())
+		}
+		for i := 0; i < writes; i++ {
+			var event watch.Event
+			Eventually(w.ResultChan()).Should(Receive(&event))
+			Expect(event.Type).To(Equal(watch.Added))
+			Expect(event.Object.(metav1.Object).GetName()).To(Equal(fmt.Sprintf("cm-%d", i)))
+		}
+
+		By("Delivering the events of as many writes to an informer")
+		informer, err := c.GetInformer(ctx, &corev1.ConfigMap{})
+		Expect(err).NotTo(HaveOccurred())
+		h := &recordingHandler{}
+		informer.AddEventHandler(h)
+		startCache()
+		for i := 0; i < writes; i++ {
+			obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("cm-%d", i), Namespace: "ns"}}
+			Expect(cl.Delete(ctx, obj)).To(Succeed())
+		}
+		Eventually(func() int { return len(h.Events()) }).Should(Equal(1 + 2*writes))
+	})
+
+	It("should run informers requested after it was started", func() {
+		startCache()
+
+		informer, err := c.GetInformer(ctx, &corev1.ConfigMap{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(informer.HasSynced()).To(BeTrue())
+		h := &recordingHandler{}
+		informer.AddEventHandler(h)
+
+		patch := client.MergeFrom(existing.DeepCopy())
+		existing.Data = map[string]string{"key": "patched"}
+		Expect(cl.Patch(ctx, existing, patch)).To(Succeed())
+		Eventually(h.Events).Should(Equal([]string{"add existing", "update existing"}))
+	})
+
+	It("should deliver unstructured objects to unstructured informers", func() {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"})
+		informer, err := c.GetInformer(ctx, u)
+		Expect(err).NotTo(HaveOccurred())
+
+		var received atomic.Value
+		informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) { received.Store(obj) },
+		})
+		startCache()
+
+		Eventually(received.Load).ShouldNot(BeNil())
+		obj, isUnstructured := received.Load().(*unstructured.Unstructured)
+		Expect(isUnstructured).To(BeTrue())
+		Expect(obj.GetKind()).To(Equal("ConfigMap"))
+		Expect(obj.GetName()).To(Equal("existing"))
+		Expect(obj.Object["data"]).To(Equal(map[string]interface{}{"key": "value"}))
+	})
+
+	It("should enqueue requests from a source.Kind", func() {
+		src := &source.Kind{Type: &corev1.ConfigMap{}}
+		Expect(src.InjectCache(c)).To(Succeed())
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+		Expect(src.Start(ctx, &handler.EnqueueRequestForObject{}, queue)).To(Succeed())
+		startCache()
+		Expect(src.WaitForSync(ctx)).To(Succeed())
+
+		item, _ := queue.Get()
+		Expect(item).To(Equal(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(existing)}))
+		queue.Done(item)
+
+		Expect(cl.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "ns"}})).To(Succeed())
+		item, _ = queue.Get()
+		Expect(item).To(Equal(reconcile.Request{NamespacedName: client.ObjectKey{Name: "new", Namespace: "ns"}}))
+		queue.Done(item)
+	})
+
+	It("should use its field indexes to list objects", func() {
+		Expect(c.IndexField(ctx, &corev1.ConfigMap{}, "data.key", func(obj client.Object) []string {
+			return []string{obj.(*corev1.ConfigMap).Data["key"]}
+		})).To(Succeed())
+		Expect(c.IndexField(ctx, &corev1.ConfigMap{}, "data.key", func(obj client.Object) []string {
+			return nil
+		})).NotTo(Succeed())
+
+		list := &corev1.ConfigMapList{}
+		Expect(c.List(ctx, list, client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector("data.key", "value")})).To(Succeed())
+		Expect(list.Items).To(HaveLen(1))
+		Expect(list.Items[0].Name).To(Equal("existing"))
+
+		By("Listing with the client")
+		Expect(cl.List(ctx, list, client.MatchingFields{"data.key": "other"})).To(Succeed())
+		Expect(list.Items).To(BeEmpty())
+	})
+
+	It("should notify handlers when an informer is removed", func() {
+		informer, err := c.GetInformer(ctx, &corev1.ConfigMap{})
+		Expect(err).NotTo(HaveOccurred())
+		h := &recordingHandler{}
+		informer.AddEventHandler(h)
+		startCache()
+		Eventually(h.Events).Should(HaveLen(1))
+
+		Expect(c.RemoveInformer(ctx, &corev1.ConfigMap{})).To(Succeed())
+		Expect(h.Removed()).To(BeTrue())
+
+		Expect(cl.Delete(ctx, existing)).To(Succeed())
+		Consistently(h.Events).Should(Equal([]string{"add existing"}))
+	})
+
+	It("should run a controller built with the builder", func() {
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+		mgr, err := manager.New(&rest.Config{}, manager.Options{
+			Scheme:             scheme.Scheme,
+			MetricsBindAddress: "0",
+			MapperProvider:     func(*rest.Config) (meta.RESTMapper, error) { return mapper, nil },
+			NewCache: func(*rest.Config, cache.Options) (cache.Cache, error) {
+				return c, nil
+			},
+			NewClient: func(cache.Cache, *rest.Config, client.Options, ...client.Object) (client.Client, error) {
+				return cl, nil
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Labeling every configmap from the reconciler")
+		err = builder.ControllerManagedBy(mgr).
+			For(&corev1.ConfigMap{}).
+			Complete(reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+				obj := &corev1.ConfigMap{}
+				if err := mgr.GetClient().Get(ctx, req.NamespacedName, obj); err != nil {
+					return reconcile.Result{}, client.IgnoreNotFound(err)
+				}
+				if obj.Labels["reconciled"] == "true" {
+					return reconcile.Result{}, nil
+				}
+				obj.Labels = map[string]string{"reconciled": "true"}
+				return reconcile.Result{}, mgr.GetClient().Update(ctx, obj)
+			}))
+		Expect(err).NotTo(HaveOccurred())
+		go func() {
+			defer GinkgoRecover()
+			Expect(mgr.Start(ctx)).To(Succeed())
+		}()
+
+		isReconciled := func(key client.ObjectKey) func() string {
+			return func() string {
+				obj := &corev1.ConfigMap{}
+				Expect(cl.Get(ctx, key, obj)).To(Succeed())
+				return obj.Labels["reconciled"]
+			}
+		}
+		Eventually(isReconciled(client.ObjectKeyFromObject(existing))).Should(Equal("true"))
+
+		created := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "created", Namespace: "ns"}}
+		Expect(cl.Create(ctx, created)).To(Succeed())
+		Eventually(isReconciled(client.ObjectKeyFromObject(created))).Should(Equal("true"))
+	})
+})
@@ -21,32 +21,93 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
-
+	"sync"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/testing"
 
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/internal/objectutil"
 )
 
 type versionedTracker struct {
 	testing.ObjectTracker
 	scheme *runtime.Scheme
+
+	// withStatusSubresource holds the kinds registered with
+	// ClientBuilder.WithStatusSubresource.
+	withStatusSubresource map[schema.GroupVersionKind]struct{}
+
+	fieldManager *fieldManager
+
+	// gc is the garbage collector of the tracker, nil unless enabled with
+	// ClientBuilder.WithGarbageCollection.
+	gc *garbageCollector
+}
+
+// writeOptions describe a write to the versionedTracker.
+type writeOptions struct {
+	// isStatus is set for writes to the status subresource.
+	isStatus bool
+	// manager is the field manager of the request, if any.
+	manager string
+	// applied is set for apply requests, whose managed fields have
+	// already been computed.
+	applied bool
+	// deleting is set when a delete request marks an object with
+	// finalizers as being deleted.
+	deleting bool
+	// collecting is set for writes made by the garbage collector, which
+	// don't trigger another collection.
+	collecting bool
+}
+
+// patchTracker is a versionedTracker whose updates are done with the given
+// options.  It is used to store patched objects.
+type patchTracker struct {
+	versionedTracker
+	opts writeOptions
+}
+
+func (t patchTracker) Update(gvr schema.GroupVersionResource, obj runtime.Object, ns string) error {
+	return t.update(gvr, obj, ns, t.opts)
 }
 
 type fakeClient struct {
 	tracker versionedTracker
 	scheme  *runtime.Scheme
+
+	// indexes maps each GroupVersionKind to the extraction functions of
+	// the fields indexed for it, keyed by field name.  Indexes can be added
+	// through the cache built with ClientBuilder.BuildWithCache.
+	indexes     map[schema.GroupVersionKind]map[string]client.IndexerFunc
+	indexesLock sync.RWMutex
 }
 
 var _ client.Client = &fakeClient{}
@@ -85,6 +146,18 @@ type ClientBuilder struct {
 	initObject         []client.Object
 	initLists          []client.ObjectList
 	initRuntimeObjects []runtime.Object
+	indexes            []fieldIndex
+
+	withStatusSubresource []client.Object
+	interceptorFuncs      *interceptor.Funcs
+	garbageCollection     bool
+}
+
+// fieldIndex is an index registered with ClientBuilder.WithIndex.
+type fieldIndex struct {
+	obj          runtime.Object
+	field        string
+	extractValue client.IndexerFunc
 }
 
 // WithScheme sets this builder's internal scheme.
@@ -112,13 +185,101 @@ func (f *ClientBuilder) WithRuntimeObjects(initRuntimeObjs ...runtime.Object) *C
 	return f
 }
 
+// WithIndex can be optionally used to register an index with name field for
+// obj, the same way client.FieldIndexer.IndexField does for the informer
+// cache.  List requests with a field selector on field are then answered
+// using extractValue: an equality requirement matches if at least one of the
+// extracted values is equal to the requested value.  Requirements on fields
+// without an index are evaluated against the objects' content, as the cache
+// does.
+func (f *ClientBuilder) WithIndex(obj runtime.Object, field string, extractValue client.IndexerFunc) *ClientBuilder {
+	f.indexes = append(f.indexes, fieldIndex{obj: obj, field: field, extractValue: extractValue})
+	return f
+}
+
+// WithStatusSubresource configures the fake client to treat the status of
+// the given kinds of objects as a subresource, like the API server does for
+// kinds that have one: Update and Patch ignore changes to the status, and
+// Status().Update and Status().Patch change nothing but the status.
+//
+// The status of other kinds is written by both the main resource and the
+// status subresource.
+func (f *ClientBuilder) WithStatusSubresource(objs ...client.Object) *ClientBuilder {
+	f.withStatusSubresource = append(f.withStatusSubresource, objs...)
+	return f
+}
+
+// WithGarbageCollection enables the simulation of the garbage collector of
+// Kubernetes: when an object is deleted, its dependents, i.e. the objects
+// whose owner references point at its UID, are deleted as well.  The
+// PropagationPolicy of delete requests is honored: Background, the default,
+// deletes the owner right away and its dependents after it, Foreground keeps
+// the owner until its dependents are gone, and Orphan removes the owner
+// references of the dependents instead of deleting them.
+//
+// Since dependents are found by UID, the fake client sets a UID on objects
+// that don't have one when garbage collection is enabled.
+func (f *ClientBuilder) WithGarbageCollection() *ClientBuilder {
+	f.garbageCollection = true
+	return f
+}
+
+// WithInterceptorFuncs configures the client methods to be intercepted
+// using the provided interceptor.Funcs, e.g. to inject errors or record
+// calls.  The intercepting functions get the fake client so that they can
+// pass calls through to it.
+func (f *ClientBuilder) WithInterceptorFuncs(interceptorFuncs interceptor.Funcs) *ClientBuilder {
+	f.interceptorFuncs = &interceptorFuncs
+	return f
+}
+
 // Build builds and returns a new fake client.
 func (f *ClientBuilder) Build() client.Client {
+	return f.intercept(f.build())
+}
+
+// BuildWithCache builds a new fake client, like Build, along with a fake
+// cache.Cache that shares its storage.  Reads from the cache return the
+// objects stored by the client, and the informers of the cache deliver an
+// event to their handlers, e.g. those of a source.Kind, for every object
+// created, updated or deleted through the client, once the cache has been
+// started.  Calls made by the cache itself are not intercepted.
+func (f *ClientBuilder) BuildWithCache() (client.Client, cache.Cache) {
+	c := f.build()
+	return f.intercept(c), newFakeCache(c)
+}
+
+// intercept wraps c with the interceptor functions of the builder, if any.
+func (f *ClientBuilder) intercept(c *fakeClient) client.Client {
+	if f.interceptorFuncs != nil {
+		return interceptor.NewClient(c, *f.interceptorFuncs)
+	}
+	return c
+}
+
+func (f *ClientBuilder) build() *fakeClient {
 	if f.scheme == nil {
 		f.scheme = scheme.Scheme
 	}
 
-	tracker := versionedTracker{ObjectTracker: testing.NewObjectTracker(f.scheme, scheme.Codecs.UniversalDecoder()), scheme: f.scheme}
+	withStatusSubresource := map[schema.GroupVersionKind]struct{}{}
+	for _, obj := range f.withStatusSubresource {
+		gvk, err := apiutil.GVKForObject(obj, f.scheme)
+		if err != nil {
+			panic(fmt.Errorf("failed to get GroupVersionKind of status subresource object %T: %w", obj, err))
+		}
+		withStatusSubresource[gvk] = struct{}{}
+	}
+
+	tracker := versionedTracker{
+		ObjectTracker:         newBufferingTracker(testing.NewObjectTracker(f.scheme, scheme.Codecs.UniversalDecoder())),
+		scheme:                f.scheme,
+		withStatusSubresource: withStatusSubresource,
+		fieldManager:          newFieldManager(f.scheme),
+	}
+	if f.garbageCollection {
+		tracker.gc = newGarbageCollector()
+	}
 	for _, obj := range f.initObject {
 		if err := tracker.Add(obj); err != nil {
 			panic(fmt.Errorf("failed to add object %v to fake client: %w", obj, err))
@@ -134,9 +295,24 @@ func (f *ClientBuilder) Build() client.Client {
 			panic(fmt.Errorf("failed to add runtime object %v to fake client: %w", obj, err))
 		}
 	}
+	indexes := map[schema.GroupVersionKind]map[string]client.IndexerFunc{}
+	for _, idx := range f.indexes {
+		gvk, err := apiutil.GVKForObject(idx.obj, f.scheme)
+		if err != nil {
+			panic(fmt.Errorf("failed to get GroupVersionKind of index object %T: %w", idx.obj, err))
+		}
+		if _, exists := indexes[gvk][idx.field]; exists {
+			panic(fmt.Errorf("an index with name %q has already been registered for GroupVersionKind %v", idx.field, gvk))
+		}
+		if indexes[gvk] == nil {
+			indexes[gvk] = map[string]client.IndexerFunc{}
+		}
+		indexes[gvk][idx.field] = idx.extractValue
+	}
 	return &fakeClient{
 		tracker: tracker,
 		scheme:  f.scheme,
+		indexes: indexes,
 	}
 }
 
@@ -165,6 +341,12 @@ func (t versionedTracker) Add(obj runtime.Object) error {
 			// be recognized
 			accessor.SetResourceVersion(trackerAddResourceVersion)
 		}
+		if err := validateDeletionTimestamp(accessor); err != nil {
+			return err
+		}
+		if err := t.trackForGarbageCollection(obj, accessor); err != nil {
+			return err
+		}
 		if err := t.ObjectTracker.Add(obj); err != nil {
 			return err
 		}
@@ -174,6 +356,10 @@ func (t versionedTracker) Add(obj runtime.Object) error {
 }
 
 func (t versionedTracker) Create(gvr schema.GroupVersionResource, obj runtime.Object, ns string) error {
+	return t.create(gvr, obj, ns, writeOptions{})
+}
+
+func (t versionedTracker) create(gvr schema.GroupVersionResource, obj runtime.Object, ns string, opts writeOptions) error {
 	accessor, err := meta.Accessor(obj)
 	if err != nil {
 		return fmt.Errorf("failed to get accessor for object: %v", err)
@@ -187,6 +373,21 @@ func (t versionedTracker) Create(gvr schema.GroupVersionResource, obj runtime.Ob
 	if accessor.GetResourceVersion() != "" {
 		return apierrors.NewBadRequest("resourceVersion can not be set for Create requests")
 	}
+	if err := validateDeletionTimestamp(accessor); err != nil {
+		return err
+	}
+	if err := t.trackForGarbageCollection(obj, accessor); err != nil {
+		return err
+	}
+	if !opts.applied && opts.manager != "" {
+		gvk, err := apiutil.GVKForObject(obj, t.scheme)
+		if err != nil {
+			return err
+		}
+		if err := t.fieldManager.update(gvk, nil, obj, opts.manager); err != nil {
+			return err
+		}
+	}
 	accessor.SetResourceVersion("1")
 	if err := t.ObjectTracker.Create(gvr, obj, ns); err != nil {
 		accessor.SetResourceVersion("")
@@ -196,6 +397,17 @@ func (t versionedTracker) Create(gvr schema.GroupVersionResource, obj runtime.Ob
 }
 
 func (t versionedTracker) Update(gvr schema.GroupVersionResource, obj runtime.Object, ns string) error {
+	return t.update(gvr, obj, ns, writeOptions{})
+}
+
+// update updates obj in the tracker.  For kinds with a status subresource,
+// status updates only change the status of the stored object, and other
+// updates keep it.  The managed fields of the object are updated unless the
+// write is an apply request.  Like the API server, updates can't change the
+// deletion timestamp of an object or add finalizers to an object that is
+// being deleted, and an object being deleted is removed once its last
+// finalizer is.
+func (t versionedTracker) update(gvr schema.GroupVersionResource, obj runtime.Object, ns string, opts writeOptions) error {
 	accessor, err := meta.Accessor(obj)
 	if err != nil {
 		return fmt.Errorf("failed to get accessor for object: %v", err)
@@ -221,7 +433,7 @@ func (t versionedTracker) Update(gvr schema.GroupVersionResource, obj runtime.Ob
 		// If the resource is not found and the resource allows create on update, issue a
 		// create instead.
 		if apierrors.IsNotFound(err) && allowsCreateOnUpdate(gvk) {
-			return t.Create(gvr, obj, ns)
+			return t.create(gvr, obj, ns, opts)
 		}
 		return err
 	}
@@ -239,6 +451,40 @@ func (t versionedTracker) Update(gvr schema.GroupVersionResource, obj runtime.Ob
 	if accessor.GetResourceVersion() != oldAccessor.GetResourceVersion() {
 		return apierrors.NewConflict(gvr.GroupResource(), accessor.GetName(), errors.New("object was modified"))
 	}
+	if !opts.deleting {
+		accessor.SetDeletionTimestamp(oldAccessor.GetDeletionTimestamp())
+		accessor.SetDeletionGracePeriodSeconds(oldAccessor.GetDeletionGracePeriodSeconds())
+	}
+	if oldAccessor.GetDeletionTimestamp() != nil {
+		if err := validateNoNewFinalizers(gvk, accessor, oldAccessor); err != nil {
+			return err
+		}
+	}
+	if _, hasStatus := t.withStatusSubresource[gvk]; hasStatus {
+		if opts.isStatus {
+			updated := oldObject.DeepCopyObject()
+			if err := copyStatusFrom(obj, updated); err != nil {
+				return err
+			}
+			objGVK, resourceVersion := obj.GetObjectKind().GroupVersionKind(), accessor.GetResourceVersion()
+			managedFields := accessor.GetManagedFields()
+			if err := copyInto(updated, obj); err != nil {
+				return err
+			}
+			obj.GetObjectKind().SetGroupVersionKind(objGVK)
+			accessor.SetResourceVersion(resourceVersion)
+			if opts.applied {
+				accessor.SetManagedFields(managedFields)
+			}
+		} else if err := copyStatusFrom(oldObject, obj); err != nil {
+			return err
+		}
+	}
+	if !opts.applied {
+		if err := t.fieldManager.update(gvk, oldObject, obj, opts.manager); err != nil {
+			return err
+		}
+	}
 	if oldAccessor.GetResourceVersion() == "" {
 		oldAccessor.SetResourceVersion("0")
 	}
@@ -249,12 +495,80 @@ func (t versionedTracker) Update(gvr schema.GroupVersionResource, obj runtime.Ob
 	intResourceVersion++
 	accessor.SetResourceVersion(strconv.FormatUint(intResourceVersion, 10))
 	if !accessor.GetDeletionTimestamp().IsZero() && len(accessor.GetFinalizers()) == 0 {
-		return t.ObjectTracker.Delete(gvr, accessor.GetNamespace(), accessor.GetName())
+		if err := t.ObjectTracker.Delete(gvr, accessor.GetNamespace(), accessor.GetName()); err != nil {
+			return err
+		}
+		return t.collectGarbage(opts)
 	}
 	return t.ObjectTracker.Update(gvr, obj, ns)
 }
 
-func (c *fakeClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+// delete deletes the object with the given name.  Objects with finalizers
+// are only marked as being deleted by setting their deletion timestamp; they
+// are removed once their last finalizer is.
+func (t versionedTracker) delete(gvr schema.GroupVersionResource, ns, name string, policy *metav1.DeletionPropagation) error {
+	if err := t.deleteObject(gvr, ns, name, policy); err != nil {
+		return err
+	}
+	return t.collectGarbage(writeOptions{})
+}
+
+// collectGarbage runs the garbage collector, if enabled, unless the write
+// that triggers it was made by the garbage collector itself.
+func (t versionedTracker) collectGarbage(opts writeOptions) error {
+	if t.gc == nil || opts.collecting {
+		return nil
+	}
+	return t.gc.collect(t)
+}
+
+// trackForGarbageCollection records the resource of obj for the garbage
+// collector, if enabled, and sets a UID on obj if it doesn't have one.
+func (t versionedTracker) trackForGarbageCollection(obj runtime.Object, accessor metav1.Object) error {
+	if t.gc == nil {
+		return nil
+	}
+	gvk, err := apiutil.GVKForObject(obj, t.scheme)
+	if err != nil {
+		return err
+	}
+	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+	t.gc.track(gvr, gvk)
+	if accessor.GetUID() == "" {
+		accessor.SetUID(uuid.NewUUID())
+	}
+	return nil
+}
+
+// validateDeletionTimestamp refuses objects that are marked as being deleted
+// without any finalizers, since they would never be removed.
+func validateDeletionTimestamp(accessor metav1.Object) error {
+	if accessor.GetDeletionTimestamp() != nil && len(accessor.GetFinalizers()) == 0 {
+		return fmt.Errorf("refusing to create object %s with metadata.deletionTimestamp but no finalizers", accessor.GetName())
+	}
+	return nil
+}
+
+// validateNoNewFinalizers returns an error if obj has finalizers that old,
+// which is being deleted, doesn't have.
+func validateNoNewFinalizers(gvk schema.GroupVersionKind, obj, old metav1.Object) error {
+	oldFinalizers := sets.NewString(old.GetFinalizers()...)
+	var added []string
+	for _, finalizer := range obj.GetFinalizers() {
+		if !oldFinalizers.Has(finalizer) {
+			added = append(added, finalizer)
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(gvk.GroupKind(), obj.GetName(), field.ErrorList{
+		field.Forbidden(field.NewPath("metadata", "finalizers"),
+			fmt.Sprintf("no new finalizers can be added if the object is being deleted, found new finalizers %q", added)),
+	})
+}
+
+func (c *fakeClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
 	gvr, err := getGVRFromObject(obj, c.scheme)
 	if err != nil {
 		return err
@@ -338,9 +652,86 @@ func (c *fakeClient) List(ctx context.Context, obj client.ObjectList, opts ...cl
 			return err
 		}
 	}
+	if listOpts.FieldSelector != nil && !listOpts.FieldSelector.Empty() {
+		objs, err := meta.ExtractList(obj)
+		if err != nil {
+			return err
+		}
+		filteredObjs, err := c.filterWithFields(gvk, objs, listOpts.FieldSelector)
+		if err != nil {
+			return err
+		}
+		err = meta.SetList(obj, filteredObjs)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// filterWithFields returns the items in objs matching the field selector.
+// Requirements on fields indexed with ClientBuilder.WithIndex are evaluated
+// against the index's values, others against the objects' content, so that
+// the result is the same as when listing from the informer cache.
+func (c *fakeClient) filterWithFields(gvk schema.GroupVersionKind, objs []runtime.Object, sel fields.Selector) ([]runtime.Object, error) {
+	c.indexesLock.RLock()
+	indexes := c.indexes[gvk]
+	c.indexesLock.RUnlock()
+	var indexed, unindexed []fields.Requirement
+	for _, req := range sel.Requirements() {
+		_, hasIndex := indexes[req.Field]
+		if hasIndex && (req.Operator == selection.Equals || req.Operator == selection.DoubleEquals || req.Operator == selection.NotEquals) {
+			indexed = append(indexed, req)
+			continue
+		}
+		unindexed = append(unindexed, req)
+	}
+
+	outItems := make([]runtime.Object, 0, len(objs))
+	for _, obj := range objs {
+		matches, err := objectMatchesIndexes(obj, indexes, indexed)
+		if err != nil {
+			return nil, err
+		}
+		if !matches {
+			continue
+		}
+		if matches, err = objectutil.MatchesFieldRequirements(obj, unindexed); err != nil {
+			return nil, err
+		}
+		if matches {
+			outItems = append(outItems, obj)
+		}
+	}
+	return outItems, nil
+}
+
+// objectMatchesIndexes evaluates field requirements on indexed fields: an
+// equality requirement matches if one of the values extracted for the field
+// is the requested value, and an inequality requirement if none is.
+func objectMatchesIndexes(obj runtime.Object, indexes map[string]client.IndexerFunc, reqs []fields.Requirement) (bool, error) {
+	if len(reqs) == 0 {
+		return true, nil
+	}
+	o, isObj := obj.(client.Object)
+	if !isObj {
+		return false, fmt.Errorf("%T does not implement client.Object", obj)
+	}
+	for _, req := range reqs {
+		found := false
+		for _, val := range indexes[req.Field](o) {
+			if val == req.Value {
+				found = true
+				break
+			}
+		}
+		if found == (req.Operator == selection.NotEquals) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 func (c *fakeClient) Scheme() *runtime.Scheme {
 	return c.scheme
 }
@@ -377,7 +768,7 @@ func (c *fakeClient) Create(ctx context.Context, obj client.Object, opts ...clie
 		accessor.SetName(fmt.Sprintf("%s%s", base, utilrand.String(randomLength)))
 	}
 
-	return c.tracker.Create(gvr, obj, accessor.GetNamespace())
+	return c.tracker.create(gvr, obj, accessor.GetNamespace(), writeOptions{manager: createOptions.FieldManager})
 }
 
 func (c *fakeClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
@@ -392,7 +783,7 @@ func (c *fakeClient) Delete(ctx context.Context, obj client.Object, opts ...clie
 	delOptions := client.DeleteOptions{}
 	delOptions.ApplyOptions(opts)
 
-	return c.deleteObject(gvr, accessor)
+	return c.tracker.delete(gvr, accessor.GetNamespace(), accessor.GetName(), delOptions.PropagationPolicy)
 }
 
 func (c *fakeClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
@@ -423,7 +814,7 @@ func (c *fakeClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ..
 		if err != nil {
 			return err
 		}
-		err = c.deleteObject(gvr, accessor)
+		err = c.tracker.delete(gvr, accessor.GetNamespace(), accessor.GetName(), dcOptions.PropagationPolicy)
 		if err != nil {
 			return err
 		}
@@ -432,6 +823,10 @@ func (c *fakeClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ..
 }
 
 func (c *fakeClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.update(obj, false, opts...)
+}
+
+func (c *fakeClient) update(obj client.Object, isStatus bool, opts ...client.UpdateOption) error {
 	updateOptions := &client.UpdateOptions{}
 	updateOptions.ApplyOptions(opts)
 
@@ -449,10 +844,14 @@ func (c *fakeClient) Update(ctx context.Context, obj client.Object, opts ...clie
 	if err != nil {
 		return err
 	}
-	return c.tracker.Update(gvr, obj, accessor.GetNamespace())
+	return c.tracker.update(gvr, obj, accessor.GetNamespace(), writeOptions{isStatus: isStatus, manager: updateOptions.FieldManager})
 }
 
 func (c *fakeClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.patch(obj, patch, false, opts...)
+}
+
+func (c *fakeClient) patch(obj client.Object, patch client.Patch, isStatus bool, opts ...client.PatchOption) error {
 	patchOptions := &client.PatchOptions{}
 	patchOptions.ApplyOptions(opts)
 
@@ -475,7 +874,29 @@ func (c *fakeClient) Patch(ctx context.Context, obj client.Object, patch client.
 		return err
 	}
 
-	reaction := testing.ObjectReaction(c.tracker)
+	if patch.Type() == types.ApplyPatchType {
+		config := &unstructured.Unstructured{}
+		if err := json.Unmarshal(data, &config.Object); err != nil {
+			return err
+		}
+		gvk, err := apiutil.GVKForObject(obj, c.scheme)
+		if err != nil {
+			return err
+		}
+		config.SetGroupVersionKind(gvk)
+		if patchOptions.FieldManager == "" {
+			return client.ErrFieldManagerRequired
+		}
+		force := patchOptions.Force != nil && *patchOptions.Force
+		applied, err := c.applyUnstructured(config, isStatus, patchOptions.FieldManager, force)
+		if err != nil {
+			return err
+		}
+		return copyInto(applied, obj)
+	}
+
+	tracker := patchTracker{versionedTracker: c.tracker, opts: writeOptions{isStatus: isStatus, manager: patchOptions.FieldManager}}
+	reaction := testing.ObjectReaction(tracker)
 	handled, o, err := reaction(testing.NewPatchAction(gvr, accessor.GetNamespace(), accessor.GetName(), patch.Type(), data))
 	if err != nil {
 		return err
@@ -504,25 +925,106 @@ func (c *fakeClient) Patch(ctx context.Context, obj client.Object, patch client.
 	return err
 }
 
-func (c *fakeClient) Status() client.StatusWriter {
-	return &fakeStatusWriter{client: c}
+// Apply implements client.Client.  Like the API server, the fake client
+// merges the apply configuration into the object using structured-merge-diff,
+// records the fields applied by the field manager in the object's managed
+// fields, removes fields the manager no longer applies, and fails on
+// conflicts with other managers unless ForceOwnership is set.
+func (c *fakeClient) Apply(ctx context.Context, obj client.ApplyConfiguration, opts ...client.ApplyOption) error {
+	return c.apply(obj, false, opts...)
+}
+
+func (c *fakeClient) apply(obj client.ApplyConfiguration, isStatus bool, opts ...client.ApplyOption) error {
+	applyOptions := &client.ApplyOptions{}
+	applyOptions.ApplyOptions(opts)
+	if applyOptions.FieldManager == "" {
+		return client.ErrFieldManagerRequired
+	}
+
+	for _, dryRunOpt := range applyOptions.DryRun {
+		if dryRunOpt == metav1.DryRunAll {
+			return nil
+		}
+	}
+
+	u, err := client.ApplyConfigurationToUnstructured(obj, c.scheme)
+	if err != nil {
+		return err
+	}
+	force := applyOptions.Force != nil && *applyOptions.Force
+	target, err := c.applyUnstructured(u, isStatus, applyOptions.FieldManager, force)
+	if err != nil {
+		return err
+	}
+	return copyInto(target, obj)
 }
 
-func (c *fakeClient) deleteObject(gvr schema.GroupVersionResource, accessor metav1.Object) error {
-	old, err := c.tracker.Get(gvr, accessor.GetNamespace(), accessor.GetName())
-	if err == nil {
-		oldAccessor, err := meta.Accessor(old)
-		if err == nil {
-			if len(oldAccessor.GetFinalizers()) > 0 {
-				now := metav1.Now()
-				oldAccessor.SetDeletionTimestamp(&now)
-				return c.tracker.Update(gvr, old, accessor.GetNamespace())
+// applyUnstructured applies config on behalf of manager and returns the
+// stored object.
+func (c *fakeClient) applyUnstructured(config *unstructured.Unstructured, isStatus bool, manager string, force bool) (client.Object, error) {
+	gvk := config.GroupVersionKind()
+	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+	live, err := c.tracker.Get(gvr, config.GetNamespace(), config.GetName())
+	exists := err == nil
+	if err != nil && (!apierrors.IsNotFound(err) || isStatus) {
+		return nil, err
+	}
+	if !exists {
+		live = nil
+	}
+
+	applied, err := c.tracker.fieldManager.apply(gvk, live, config, manager, force)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prefer the typed representation of the object, so that it is stored
+	// in the tracker the same way as objects written with Create and Update.
+	var target client.Object = applied
+	if typed, err := c.scheme.New(gvk); err == nil {
+		if typedObj, isObject := typed.(client.Object); isObject {
+			if err := copyInto(applied, typedObj); err != nil {
+				return nil, err
 			}
+			target = typedObj
 		}
 	}
 
-	//TODO: implement propagation
-	return c.tracker.Delete(gvr, accessor.GetNamespace(), accessor.GetName())
+	if !exists {
+		target.SetResourceVersion("")
+		err = c.tracker.create(gvr, target, target.GetNamespace(), writeOptions{applied: true})
+	} else {
+		err = c.tracker.update(gvr, target, target.GetNamespace(), writeOptions{isStatus: isStatus, applied: true})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// copyInto serializes src and decodes it into dst, which may be a typed
+// object, an unstructured object or an apply configuration.
+func copyInto(src, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	if u, isUnstructured := dst.(*unstructured.Unstructured); isUnstructured {
+		u.Object = nil
+		return u.UnmarshalJSON(data)
+	}
+	// reset the object, since unmarshal does not clear fields that are not
+	// present in the result
+	value := reflect.ValueOf(dst)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return fmt.Errorf("expected a non-nil pointer, got %T", dst)
+	}
+	value.Elem().Set(reflect.Zero(value.Elem().Type()))
+	return json.Unmarshal(data, dst)
+}
+
+func (c *fakeClient) Status() client.StatusWriter {
+	return &fakeStatusWriter{client: c}
 }
 
 func getGVRFromObject(obj runtime.Object, scheme *runtime.Scheme) (schema.GroupVersionResource, error) {
@@ -538,16 +1040,319 @@ type fakeStatusWriter struct {
 	client *fakeClient
 }
 
+// Update implements client.StatusWriter.  Only the status of kinds
+// registered with ClientBuilder.WithStatusSubresource is written, the whole
+// object is written for other kinds.
 func (sw *fakeStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
-	// TODO(droot): This results in full update of the obj (spec + status). Need
-	// a way to update status field only.
-	return sw.client.Update(ctx, obj, opts...)
+	return sw.client.update(obj, true, opts...)
 }
 
+// Patch implements client.StatusWriter.  Only the status of kinds registered
+// with ClientBuilder.WithStatusSubresource is written, the whole object is
+// written for other kinds.
 func (sw *fakeStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
-	// TODO(droot): This results in full update of the obj (spec + status). Need
-	// a way to update status field only.
-	return sw.client.Patch(ctx, obj, patch, opts...)
+	return sw.client.patch(obj, patch, true, opts...)
+}
+
+// Apply implements client.StatusWriter.  Only the status of kinds registered
+// with ClientBuilder.WithStatusSubresource is written, the whole object is
+// written for other kinds.
+func (sw *fakeStatusWriter) Apply(ctx context.Context, obj client.ApplyConfiguration, opts ...client.ApplyOption) error {
+	return sw.client.apply(obj, true, opts...)
+}
+
+// copyStatusFrom sets the status of dst to the status of src.
+func copyStatusFrom(src, dst runtime.Object) error {
+	srcContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(src)
+	if err != nil {
+		return err
+	}
+	dstContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(dst)
+	if err != nil {
+		return err
+	}
+	if status, hasStatus := srcContent["status"]; hasStatus {
+		dstContent["status"] = status
+	} else {
+		delete(dstContent, "status")
+	}
+	return copyInto(&unstructured.Unstructured{Object: dstContent}, dst)
+}
+
+const (
+	subResourceStatus   = "status"
+	subResourceScale    = "scale"
+	subResourceEviction = "eviction"
+	subResourceToken    = "token"
+)
+
+func (c *fakeClient) SubResource(subResource string) client.SubResourceClient {
+	return &fakeSubResourceClient{client: c, subResource: subResource}
+}
+
+// fakeSubResourceClient emulates the scale, eviction and token subresources.
+// Updates and patches of any other subresource are written to the object
+// itself, like the status subresource.
+type fakeSubResourceClient struct {
+	client      *fakeClient
+	subResource string
+}
+
+func (sc *fakeSubResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object) error {
+	switch sc.subResource {
+	case subResourceScale:
+		if err := sc.client.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			return err
+		}
+		scale, err := objectToScale(obj)
+		if err != nil {
+			return err
+		}
+		return copyInto(scale, subResource)
+	default:
+		return fmt.Errorf("fake client does not support getting the %s subresource", sc.subResource)
+	}
+}
+
+func (sc *fakeSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	createOptions := &client.SubResourceCreateOptions{}
+	createOptions.ApplyOptions(opts)
+
+	switch sc.subResource {
+	case subResourceEviction:
+		if _, isEviction := subResource.(*policyv1beta1.Eviction); !isEviction && subResource.GetObjectKind().GroupVersionKind().Kind != "Eviction" {
+			return fmt.Errorf("got invalid type %T, expected Eviction", subResource)
+		}
+		if _, isPod := obj.(*corev1.Pod); !isPod && obj.GetObjectKind().GroupVersionKind().Kind != "Pod" {
+			return apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, obj.GetName())
+		}
+		if err := sc.client.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			return err
+		}
+		for _, dryRunOpt := range createOptions.DryRun {
+			if dryRunOpt == metav1.DryRunAll {
+				return nil
+			}
+		}
+		return sc.client.Delete(ctx, obj)
+	case subResourceToken:
+		tokenRequest := &authenticationv1.TokenRequest{}
+		if err := copyInto(subResource, tokenRequest); err != nil {
+			return fmt.Errorf("got invalid type %T, expected TokenRequest: %w", subResource, err)
+		}
+		if _, isServiceAccount := obj.(*corev1.ServiceAccount); !isServiceAccount && obj.GetObjectKind().GroupVersionKind().Kind != "ServiceAccount" {
+			return apierrors.NewNotFound(schema.GroupResource{Resource: "serviceaccounts"}, obj.GetName())
+		}
+		if err := sc.client.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			return err
+		}
+
+		expirationSeconds := int64(3600)
+		if tokenRequest.Spec.ExpirationSeconds != nil {
+			expirationSeconds = *tokenRequest.Spec.ExpirationSeconds
+		}
+		tokenRequest.Status.Token = "fake-token-" + utilrand.String(randomLength)
+		tokenRequest.Status.ExpirationTimestamp = metav1.NewTime(time.Now().Add(time.Duration(expirationSeconds) * time.Second))
+		return copyInto(tokenRequest, subResource)
+	default:
+		return fmt.Errorf("fake client does not support creating the %s subresource", sc.subResource)
+	}
+}
+
+func (sc *fakeSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	updateOptions := &client.SubResourceUpdateOptions{}
+	updateOptions.ApplyOptions(opts)
+
+	if sc.subResource != subResourceScale {
+		body := obj
+		if updateOptions.SubResourceBody != nil {
+			body = updateOptions.SubResourceBody
+		}
+		return sc.client.update(body, sc.subResource == subResourceStatus, &updateOptions.UpdateOptions)
+	}
+
+	if updateOptions.SubResourceBody == nil {
+		return fmt.Errorf("a Scale must be passed with client.WithSubResourceBody to update the scale subresource")
+	}
+	scale := &autoscalingv1.Scale{}
+	if err := copyInto(updateOptions.SubResourceBody, scale); err != nil {
+		return err
+	}
+	if err := sc.client.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return err
+	}
+	if err := sc.updateScale(ctx, obj, scale, &updateOptions.UpdateOptions); err != nil {
+		return err
+	}
+	return copyInto(scale, updateOptions.SubResourceBody)
+}
+
+func (sc *fakeSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	patchOptions := &client.SubResourcePatchOptions{}
+	patchOptions.ApplyOptions(opts)
+
+	body := obj
+	if patchOptions.SubResourceBody != nil {
+		body = patchOptions.SubResourceBody
+	}
+	if sc.subResource != subResourceScale {
+		return sc.client.patch(body, patch, sc.subResource == subResourceStatus, &patchOptions.PatchOptions)
+	}
+
+	data, err := patch.Data(body)
+	if err != nil {
+		return err
+	}
+	if err := sc.client.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return err
+	}
+	scale, err := objectToScale(obj)
+	if err != nil {
+		return err
+	}
+	original, err := json.Marshal(scale)
+	if err != nil {
+		return err
+	}
+
+	var patched []byte
+	switch patch.Type() {
+	case types.JSONPatchType:
+		jsonPatch, err := jsonpatch.DecodePatch(data)
+		if err != nil {
+			return err
+		}
+		patched, err = jsonPatch.Apply(original)
+		if err != nil {
+			return err
+		}
+	case types.MergePatchType:
+		patched, err = jsonpatch.MergePatch(original, data)
+	case types.StrategicMergePatchType:
+		patched, err = strategicpatch.StrategicMergePatch(original, data, &autoscalingv1.Scale{})
+	default:
+		return fmt.Errorf("fake client does not support %s patches of the scale subresource", patch.Type())
+	}
+	if err != nil {
+		return err
+	}
+
+	scale = &autoscalingv1.Scale{}
+	if err := json.Unmarshal(patched, scale); err != nil {
+		return err
+	}
+	if err := sc.updateScale(ctx, obj, scale, &client.UpdateOptions{DryRun: patchOptions.DryRun, FieldManager: patchOptions.FieldManager}); err != nil {
+		return err
+	}
+	if patchOptions.SubResourceBody != nil {
+		return copyInto(scale, patchOptions.SubResourceBody)
+	}
+	return nil
+}
+
+// updateScale writes the replicas of the given scale to obj, and updates
+// scale with the result.
+func (sc *fakeSubResourceClient) updateScale(ctx context.Context, obj client.Object, scale *autoscalingv1.Scale, opts client.UpdateOption) error {
+	u := &unstructured.Unstructured{}
+	if err := copyInto(obj, u); err != nil {
+		return err
+	}
+	if err := unstructured.SetNestedField(u.Object, int64(scale.Spec.Replicas), "spec", "replicas"); err != nil {
+		return err
+	}
+	if scale.ResourceVersion != "" {
+		u.SetResourceVersion(scale.ResourceVersion)
+	}
+	if err := copyInto(u, obj); err != nil {
+		return err
+	}
+	if err := sc.client.Update(ctx, obj, opts); err != nil {
+		return err
+	}
+
+	updated, err := objectToScale(obj)
+	if err != nil {
+		return err
+	}
+	*scale = *updated
+	return nil
+}
+
+// replicasDefaultedToOne are the group kinds, as formatted by GroupKind.String, whose
+// spec.replicas is defaulted to 1 by the API server.
+var replicasDefaultedToOne = sets.NewString(
+	"Deployment.apps",
+	"ReplicaSet.apps",
+	"StatefulSet.apps",
+	"Deployment.extensions",
+	"ReplicaSet.extensions",
+	"ReplicationController",
+)
+
+// objectToScale builds the Scale of an object with spec.replicas, such as a
+// Deployment, ReplicaSet or StatefulSet.
+func objectToScale(obj client.Object) (*autoscalingv1.Scale, error) {
+	u := &unstructured.Unstructured{}
+	if err := copyInto(obj, u); err != nil {
+		return nil, err
+	}
+
+	replicas, found, err := unstructured.NestedInt64(u.Object, "spec", "replicas")
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		if !replicasDefaultedToOne.Has(u.GroupVersionKind().GroupKind().String()) {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("%s %s has no spec.replicas and can not be scaled", u.GetKind(), u.GetName()))
+		}
+		// The API server defaults the replicas of these kinds to 1.
+		replicas = 1
+	}
+	statusReplicas, _, err := unstructured.NestedInt64(u.Object, "status", "replicas")
+	if err != nil {
+		return nil, err
+	}
+
+	var selector string
+	// Typed objects serialize an unset selector as null.
+	if rawSelector, found, err := unstructured.NestedFieldNoCopy(u.Object, "spec", "selector"); err != nil {
+		return nil, err
+	} else if found && rawSelector != nil {
+		rawSelector, ok := rawSelector.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s %s has an invalid spec.selector", u.GetKind(), u.GetName())
+		}
+		labelSelector := &metav1.LabelSelector{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawSelector, labelSelector); err != nil {
+			return nil, err
+		}
+		parsed, err := metav1.LabelSelectorAsSelector(labelSelector)
+		if err != nil {
+			return nil, err
+		}
+		selector = parsed.String()
+	}
+
+	return &autoscalingv1.Scale{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "autoscaling/v1",
+			Kind:       "Scale",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              u.GetName(),
+			Namespace:         u.GetNamespace(),
+			UID:               u.GetUID(),
+			ResourceVersion:   u.GetResourceVersion(),
+			CreationTimestamp: u.GetCreationTimestamp(),
+		},
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: int32(replicas),
+		},
+		Status: autoscalingv1.ScaleStatus{
+			Replicas: int32(statusReplicas),
+			Selector: selector,
+		},
+	}, nil
 }
 
 func allowsUnconditionalUpdate(gvk schema.GroupVersionKind) bool {
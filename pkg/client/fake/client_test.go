@@ -20,20 +20,32 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	appsv1 "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	appsv1ac "k8s.io/client-go/applyconfigurations/apps/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 var _ = Describe("Fake client", func() {
@@ -484,6 +496,118 @@ var _ = Describe("Fake client", func() {
 			Expect(apierrors.IsNotFound(err)).To(BeTrue())
 		})
 
+		It("should keep the deletion timestamp of an object being deleted", func() {
+			namespacedName := types.NamespacedName{
+				Name:      "test-cm",
+				Namespace: "delete-with-finalizers",
+			}
+			By("Creating a new object with a finalizer")
+			newObj := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       namespacedName.Name,
+					Namespace:  namespacedName.Namespace,
+					Finalizers: []string{"finalizers.sigs.k8s.io/test"},
+				},
+			}
+			err := cl.Create(context.Background(), newObj)
+			Expect(err).To(BeNil())
+
+			By("Deleting the object twice")
+			err = cl.Delete(context.Background(), newObj)
+			Expect(err).To(BeNil())
+			obj := &corev1.ConfigMap{}
+			err = cl.Get(context.Background(), namespacedName, obj)
+			Expect(err).To(BeNil())
+			Expect(obj.DeletionTimestamp).NotTo(BeNil())
+			deletionTimestamp := obj.DeletionTimestamp
+			resourceVersion := obj.ResourceVersion
+
+			err = cl.Delete(context.Background(), newObj)
+			Expect(err).To(BeNil())
+			err = cl.Get(context.Background(), namespacedName, obj)
+			Expect(err).To(BeNil())
+			Expect(obj.DeletionTimestamp.Equal(deletionTimestamp)).To(BeTrue())
+			Expect(obj.ResourceVersion).To(Equal(resourceVersion))
+
+			By("Updating the object without a deletion timestamp")
+			obj.DeletionTimestamp = nil
+			obj.Data = map[string]string{"test-key": "new-value"}
+			err = cl.Update(context.Background(), obj)
+			Expect(err).To(BeNil())
+			Expect(obj.DeletionTimestamp.Equal(deletionTimestamp)).To(BeTrue())
+
+			obj = &corev1.ConfigMap{}
+			err = cl.Get(context.Background(), namespacedName, obj)
+			Expect(err).To(BeNil())
+			Expect(obj.DeletionTimestamp.Equal(deletionTimestamp)).To(BeTrue())
+			Expect(obj.Data).To(Equal(map[string]string{"test-key": "new-value"}))
+		})
+
+		It("should refuse to add finalizers to an object being deleted", func() {
+			namespacedName := types.NamespacedName{
+				Name:      "test-cm",
+				Namespace: "delete-with-finalizers",
+			}
+			By("Creating and deleting an object with a finalizer")
+			newObj := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       namespacedName.Name,
+					Namespace:  namespacedName.Namespace,
+					Finalizers: []string{"finalizers.sigs.k8s.io/test"},
+				},
+			}
+			err := cl.Create(context.Background(), newObj)
+			Expect(err).To(BeNil())
+			err = cl.Delete(context.Background(), newObj)
+			Expect(err).To(BeNil())
+
+			By("Adding a finalizer with Update")
+			obj := &corev1.ConfigMap{}
+			err = cl.Get(context.Background(), namespacedName, obj)
+			Expect(err).To(BeNil())
+			controllerutil.AddFinalizer(obj, "finalizers.sigs.k8s.io/other")
+			err = cl.Update(context.Background(), obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+
+			By("Adding a finalizer with Patch")
+			obj = &corev1.ConfigMap{}
+			err = cl.Get(context.Background(), namespacedName, obj)
+			Expect(err).To(BeNil())
+			original := obj.DeepCopy()
+			controllerutil.AddFinalizer(obj, "finalizers.sigs.k8s.io/other")
+			err = cl.Patch(context.Background(), obj, client.MergeFrom(original))
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+
+			By("Removing the last finalizer with Patch")
+			obj = &corev1.ConfigMap{}
+			err = cl.Get(context.Background(), namespacedName, obj)
+			Expect(err).To(BeNil())
+			Expect(obj.Finalizers).To(Equal([]string{"finalizers.sigs.k8s.io/test"}))
+			original = obj.DeepCopy()
+			controllerutil.RemoveFinalizer(obj, "finalizers.sigs.k8s.io/test")
+			err = cl.Patch(context.Background(), obj, client.MergeFrom(original))
+			Expect(err).To(BeNil())
+
+			err = cl.Get(context.Background(), namespacedName, &corev1.ConfigMap{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should refuse objects being deleted without finalizers", func() {
+			now := metav1.Now()
+			obj := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "deleted-cm",
+					Namespace:         "delete-with-finalizers",
+					DeletionTimestamp: &now,
+				},
+			}
+			err := cl.Create(context.Background(), obj)
+			Expect(err).To(HaveOccurred())
+			Expect(func() {
+				NewClientBuilder().WithObjects(obj.DeepCopy()).Build()
+			}).To(Panic())
+		})
+
 		It("should be able to Delete a Collection", func() {
 			By("Deleting a deploymentList")
 			err := cl.DeleteAllOf(context.Background(), &appsv1.Deployment{}, client.InNamespace("ns1"))
@@ -647,6 +771,188 @@ var _ = Describe("Fake client", func() {
 			err = cl.Get(context.Background(), namespacedName, obj)
 			Expect(apierrors.IsNotFound(err)).To(BeTrue())
 		})
+
+		It("should be able to Apply a new object", func() {
+			By("Applying a configmap that does not exist")
+			applyConfig := corev1ac.ConfigMap("applied-cm", "ns2").
+				WithData(map[string]string{"test-key": "applied-value"})
+			err := cl.Apply(context.Background(), applyConfig, client.FieldOwner("test-owner"))
+			Expect(err).To(BeNil())
+			Expect(*applyConfig.ResourceVersion).To(Equal("1"))
+
+			By("Getting the applied configmap")
+			obj := &corev1.ConfigMap{}
+			err = cl.Get(context.Background(), types.NamespacedName{Name: "applied-cm", Namespace: "ns2"}, obj)
+			Expect(err).To(BeNil())
+			Expect(obj.Data).To(Equal(map[string]string{"test-key": "applied-value"}))
+		})
+
+		It("should be able to Apply to an existing object", func() {
+			By("Applying a configmap that already exists")
+			applyConfig := corev1ac.ConfigMap("test-cm", "ns2").
+				WithData(map[string]string{"applied-key": "applied-value"})
+			err := cl.Apply(context.Background(), applyConfig, client.FieldOwner("test-owner"))
+			Expect(err).To(BeNil())
+
+			By("Checking the merged result was returned")
+			Expect(applyConfig.Data).To(Equal(map[string]string{
+				"test-key":    "test-value",
+				"applied-key": "applied-value",
+			}))
+			Expect(*applyConfig.ResourceVersion).To(Equal("1000"))
+		})
+
+		It("should be able to Apply using unstructured", func() {
+			u := &unstructured.Unstructured{}
+			u.SetAPIVersion("v1")
+			u.SetKind("ConfigMap")
+			u.SetName("test-cm")
+			u.SetNamespace("ns2")
+			u.SetLabels(map[string]string{"applied": "true"})
+			err := cl.Apply(context.Background(), u, client.FieldOwner("test-owner"))
+			Expect(err).To(BeNil())
+			Expect(u.GetLabels()).To(Equal(map[string]string{"applied": "true"}))
+			Expect(u.GetKind()).To(Equal("ConfigMap"))
+
+			obj := &corev1.ConfigMap{}
+			err = cl.Get(context.Background(), types.NamespacedName{Name: "test-cm", Namespace: "ns2"}, obj)
+			Expect(err).To(BeNil())
+			Expect(obj.Labels).To(Equal(map[string]string{"applied": "true"}))
+			Expect(obj.Data).To(Equal(cm.Data))
+		})
+
+		It("should refuse to Apply without a field manager", func() {
+			applyConfig := corev1ac.ConfigMap("test-cm", "ns2")
+			err := cl.Apply(context.Background(), applyConfig)
+			Expect(err).To(Equal(client.ErrFieldManagerRequired))
+		})
+
+		It("should not Apply with the DryRun option", func() {
+			applyConfig := corev1ac.ConfigMap("applied-cm", "ns2")
+			err := cl.Apply(context.Background(), applyConfig, client.FieldOwner("test-owner"), client.DryRunAll)
+			Expect(err).To(BeNil())
+
+			obj := &corev1.ConfigMap{}
+			err = cl.Get(context.Background(), types.NamespacedName{Name: "applied-cm", Namespace: "ns2"}, obj)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		Context("with subresources", func() {
+			var scalable *appsv1.Deployment
+			BeforeEach(func() {
+				replicas := int32(2)
+				scalable = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "scalable-deployment",
+						Namespace: "ns1",
+					},
+					Spec: appsv1.DeploymentSpec{
+						Replicas: &replicas,
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}},
+					},
+				}
+				Expect(cl.Create(context.Background(), scalable)).To(Succeed())
+			})
+
+			It("should be able to Get the scale subresource", func() {
+				scale := &autoscalingv1.Scale{}
+				err := cl.SubResource("scale").Get(context.Background(), scalable, scale)
+				Expect(err).To(BeNil())
+				Expect(scale.Name).To(Equal("scalable-deployment"))
+				Expect(scale.Spec.Replicas).To(BeEquivalentTo(2))
+				Expect(scale.Status.Selector).To(Equal("foo=bar"))
+			})
+
+			It("should default the replicas of the scale subresource to 1 when unset", func() {
+				unset := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "unset-replicas", Namespace: "ns1"}}
+				Expect(cl.Create(context.Background(), unset)).To(Succeed())
+
+				scale := &autoscalingv1.Scale{}
+				Expect(cl.SubResource("scale").Get(context.Background(), unset, scale)).To(Succeed())
+				Expect(scale.Spec.Replicas).To(BeEquivalentTo(1))
+
+				scale = &autoscalingv1.Scale{Spec: autoscalingv1.ScaleSpec{Replicas: 3}}
+				Expect(cl.SubResource("scale").Update(context.Background(), unset, client.WithSubResourceBody(scale))).To(Succeed())
+				Expect(*unset.Spec.Replicas).To(BeEquivalentTo(3))
+			})
+
+			It("should refuse to scale objects without replicas", func() {
+				cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "not-scalable", Namespace: "ns1"}}
+				Expect(cl.Create(context.Background(), cm)).To(Succeed())
+
+				err := cl.SubResource("scale").Get(context.Background(), cm, &autoscalingv1.Scale{})
+				Expect(apierrors.IsBadRequest(err)).To(BeTrue())
+			})
+
+			It("should be able to Update the scale subresource", func() {
+				scale := &autoscalingv1.Scale{Spec: autoscalingv1.ScaleSpec{Replicas: 5}}
+				err := cl.SubResource("scale").Update(context.Background(), scalable, client.WithSubResourceBody(scale))
+				Expect(err).To(BeNil())
+				Expect(scale.Spec.Replicas).To(BeEquivalentTo(5))
+
+				obj := &appsv1.Deployment{}
+				err = cl.Get(context.Background(), client.ObjectKeyFromObject(scalable), obj)
+				Expect(err).To(BeNil())
+				Expect(*obj.Spec.Replicas).To(BeEquivalentTo(5))
+				Expect(obj.ResourceVersion).To(Equal(scale.ResourceVersion))
+			})
+
+			It("should be able to Patch the scale subresource", func() {
+				scale := &autoscalingv1.Scale{}
+				err := cl.SubResource("scale").Patch(context.Background(), scalable,
+					client.RawPatch(types.MergePatchType, []byte(`{"spec":{"replicas":7}}`)),
+					client.WithSubResourceBody(scale))
+				Expect(err).To(BeNil())
+				Expect(scale.Spec.Replicas).To(BeEquivalentTo(7))
+
+				obj := &appsv1.Deployment{}
+				err = cl.Get(context.Background(), client.ObjectKeyFromObject(scalable), obj)
+				Expect(err).To(BeNil())
+				Expect(*obj.Spec.Replicas).To(BeEquivalentTo(7))
+			})
+
+			It("should be able to Create an eviction for a pod", func() {
+				pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "evicted-pod", Namespace: "ns1"}}
+				Expect(cl.Create(context.Background(), pod)).To(Succeed())
+
+				eviction := &policyv1beta1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: "evicted-pod", Namespace: "ns1"}}
+				err := cl.SubResource("eviction").Create(context.Background(), pod, eviction)
+				Expect(err).To(BeNil())
+
+				err = cl.Get(context.Background(), client.ObjectKeyFromObject(pod), &corev1.Pod{})
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should return not found when evicting a pod that does not exist", func() {
+				pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "missing-pod", Namespace: "ns1"}}
+				eviction := &policyv1beta1.Eviction{}
+				err := cl.SubResource("eviction").Create(context.Background(), pod, eviction)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should be able to Create a token for a service account", func() {
+				sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "sa", Namespace: "ns1"}}
+				Expect(cl.Create(context.Background(), sa)).To(Succeed())
+
+				tokenRequest := &authenticationv1.TokenRequest{}
+				err := cl.SubResource("token").Create(context.Background(), sa, tokenRequest)
+				Expect(err).To(BeNil())
+				Expect(tokenRequest.Status.Token).NotTo(BeEmpty())
+				Expect(tokenRequest.Status.ExpirationTimestamp.Time).To(BeTemporally(">", time.Now()))
+			})
+
+			It("should write other subresources to the object", func() {
+				obj := &appsv1.Deployment{}
+				Expect(cl.Get(context.Background(), client.ObjectKeyFromObject(scalable), obj)).To(Succeed())
+				obj.Status.Replicas = 2
+				err := cl.SubResource("status").Update(context.Background(), obj)
+				Expect(err).To(BeNil())
+
+				actual := &appsv1.Deployment{}
+				Expect(cl.Get(context.Background(), client.ObjectKeyFromObject(scalable), actual)).To(Succeed())
+				Expect(actual.Status.Replicas).To(BeEquivalentTo(2))
+			})
+		})
 	}
 
 	Context("with default scheme.Scheme", func() {
@@ -675,6 +981,441 @@ var _ = Describe("Fake client", func() {
 		AssertClientBehavior()
 	})
 
+	Context("with field indexes", func() {
+		replicas := func(obj client.Object) []string {
+			dep := obj.(*appsv1.Deployment)
+			if dep.Spec.Replicas == nil {
+				return nil
+			}
+			return []string{fmt.Sprint(*dep.Spec.Replicas)}
+		}
+
+		BeforeEach(func() {
+			one, two := int32(1), int32(2)
+			dep.Spec.Replicas = &one
+			dep2.Spec.Replicas = &two
+			cl = NewClientBuilder().
+				WithObjects(dep, dep2, cm).
+				WithIndex(&appsv1.Deployment{}, "replicas", replicas).
+				Build()
+		})
+
+		It("should filter by indexed fields", func() {
+			list := &appsv1.DeploymentList{}
+			Expect(cl.List(context.Background(), list, client.MatchingFields{"replicas": "2"})).To(Succeed())
+			Expect(list.Items).To(HaveLen(1))
+			Expect(list.Items[0].Name).To(Equal(dep2.Name))
+		})
+
+		It("should support inequality requirements on indexed fields", func() {
+			list := &appsv1.DeploymentList{}
+			sel := fields.OneTermNotEqualSelector("replicas", "2")
+			Expect(cl.List(context.Background(), list, client.MatchingFieldsSelector{Selector: sel})).To(Succeed())
+			Expect(list.Items).To(HaveLen(1))
+			Expect(list.Items[0].Name).To(Equal(dep.Name))
+		})
+
+		It("should combine field selectors with namespaces and labels", func() {
+			list := &appsv1.DeploymentList{}
+			Expect(cl.List(context.Background(), list,
+				client.InNamespace("ns1"),
+				client.MatchingLabels{"test-label": "label-value"},
+				client.MatchingFields{"replicas": "1"},
+			)).To(Succeed())
+			Expect(list.Items).To(BeEmpty())
+		})
+
+		It("should evaluate fields without an index against the objects", func() {
+			list := &appsv1.DeploymentList{}
+			Expect(cl.List(context.Background(), list, client.MatchingFields{
+				"metadata.name": dep.Name,
+				"replicas":      "1",
+			})).To(Succeed())
+			Expect(list.Items).To(HaveLen(1))
+			Expect(list.Items[0].Name).To(Equal(dep.Name))
+		})
+
+		It("should return the same error as the cache for fields that can't be evaluated", func() {
+			list := &appsv1.DeploymentList{}
+			err := cl.List(context.Background(), list, client.MatchingFields{"spec": "foo"})
+			Expect(err).To(MatchError(ContainSubstring(`field selector for "spec" does not refer to a scalar field`)))
+		})
+
+		It("should refuse to register the same index twice", func() {
+			Expect(func() {
+				NewClientBuilder().
+					WithIndex(&appsv1.Deployment{}, "replicas", replicas).
+					WithIndex(&appsv1.Deployment{}, "replicas", replicas).
+					Build()
+			}).To(Panic())
+		})
+	})
+
+	Context("with the status subresource", func() {
+		var key client.ObjectKey
+
+		BeforeEach(func() {
+			one := int32(1)
+			dep.Spec.Replicas = &one
+			dep.Status.Replicas = 1
+			key = client.ObjectKeyFromObject(dep)
+			cl = NewClientBuilder().
+				WithObjects(dep, cm).
+				WithStatusSubresource(&appsv1.Deployment{}).
+				Build()
+		})
+
+		It("should ignore status changes on Update", func() {
+			obj := &appsv1.Deployment{}
+			Expect(cl.Get(context.Background(), key, obj)).To(Succeed())
+			three := int32(3)
+			obj.Spec.Replicas = &three
+			obj.Status.Replicas = 3
+			Expect(cl.Update(context.Background(), obj)).To(Succeed())
+			Expect(obj.Status.Replicas).To(BeEquivalentTo(1))
+
+			actual := &appsv1.Deployment{}
+			Expect(cl.Get(context.Background(), key, actual)).To(Succeed())
+			Expect(*actual.Spec.Replicas).To(BeEquivalentTo(3))
+			Expect(actual.Status.Replicas).To(BeEquivalentTo(1))
+			Expect(actual.ResourceVersion).To(Equal(obj.ResourceVersion))
+		})
+
+		It("should ignore status changes on Patch", func() {
+			obj := &appsv1.Deployment{}
+			Expect(cl.Get(context.Background(), key, obj)).To(Succeed())
+			original := obj.DeepCopy()
+			obj.Status.Replicas = 3
+			Expect(cl.Patch(context.Background(), obj, client.MergeFrom(original))).To(Succeed())
+
+			actual := &appsv1.Deployment{}
+			Expect(cl.Get(context.Background(), key, actual)).To(Succeed())
+			Expect(actual.Status.Replicas).To(BeEquivalentTo(1))
+		})
+
+		It("should only change the status on Status().Update", func() {
+			obj := &appsv1.Deployment{}
+			Expect(cl.Get(context.Background(), key, obj)).To(Succeed())
+			three := int32(3)
+			obj.Spec.Replicas = &three
+			obj.Status.Replicas = 3
+			Expect(cl.Status().Update(context.Background(), obj)).To(Succeed())
+			Expect(*obj.Spec.Replicas).To(BeEquivalentTo(1))
+
+			actual := &appsv1.Deployment{}
+			Expect(cl.Get(context.Background(), key, actual)).To(Succeed())
+			Expect(*actual.Spec.Replicas).To(BeEquivalentTo(1))
+			Expect(actual.Status.Replicas).To(BeEquivalentTo(3))
+			Expect(actual.ResourceVersion).To(Equal(obj.ResourceVersion))
+		})
+
+		It("should only change the status on Status().Patch", func() {
+			obj := &appsv1.Deployment{}
+			Expect(cl.Get(context.Background(), key, obj)).To(Succeed())
+			original := obj.DeepCopy()
+			obj.Labels = map[string]string{"foo": "bar"}
+			obj.Status.Replicas = 3
+			Expect(cl.Status().Patch(context.Background(), obj, client.MergeFrom(original))).To(Succeed())
+
+			actual := &appsv1.Deployment{}
+			Expect(cl.Get(context.Background(), key, actual)).To(Succeed())
+			Expect(actual.Labels).To(BeEmpty())
+			Expect(actual.Status.Replicas).To(BeEquivalentTo(3))
+		})
+
+		It("should only change the status through the status subresource client", func() {
+			obj := &appsv1.Deployment{}
+			Expect(cl.Get(context.Background(), key, obj)).To(Succeed())
+			three := int32(3)
+			obj.Spec.Replicas = &three
+			obj.Status.Replicas = 3
+			Expect(cl.SubResource("status").Update(context.Background(), obj)).To(Succeed())
+
+			actual := &appsv1.Deployment{}
+			Expect(cl.Get(context.Background(), key, actual)).To(Succeed())
+			Expect(*actual.Spec.Replicas).To(BeEquivalentTo(1))
+			Expect(actual.Status.Replicas).To(BeEquivalentTo(3))
+		})
+
+		It("should reject status updates with a stale ResourceVersion", func() {
+			obj := &appsv1.Deployment{}
+			Expect(cl.Get(context.Background(), key, obj)).To(Succeed())
+			obj.ResourceVersion = "1"
+			obj.Status.Replicas = 3
+			err := cl.Status().Update(context.Background(), obj)
+			Expect(apierrors.IsConflict(err)).To(BeTrue())
+		})
+
+		It("should write the whole object of other kinds on Status().Update", func() {
+			obj := &corev1.ConfigMap{}
+			Expect(cl.Get(context.Background(), client.ObjectKeyFromObject(cm), obj)).To(Succeed())
+			obj.Data = map[string]string{"foo": "bar"}
+			Expect(cl.Status().Update(context.Background(), obj)).To(Succeed())
+
+			actual := &corev1.ConfigMap{}
+			Expect(cl.Get(context.Background(), client.ObjectKeyFromObject(cm), actual)).To(Succeed())
+			Expect(actual.Data).To(Equal(map[string]string{"foo": "bar"}))
+		})
+	})
+
+	Context("with server-side apply", func() {
+		var key client.ObjectKey
+
+		BeforeEach(func() {
+			cl = NewClientBuilder().Build()
+			key = client.ObjectKey{Namespace: "ns1", Name: "applied"}
+		})
+
+		applyData := func(manager string, data map[string]string, opts ...client.ApplyOption) error {
+			applyConfig := corev1ac.ConfigMap(key.Name, key.Namespace).WithData(data)
+			return cl.Apply(context.Background(), applyConfig, append(opts, client.FieldOwner(manager))...)
+		}
+
+		getData := func() map[string]string {
+			obj := &corev1.ConfigMap{}
+			Expect(cl.Get(context.Background(), key, obj)).To(Succeed())
+			return obj.Data
+		}
+
+		It("should record the applied fields in the managed fields", func() {
+			Expect(applyData("manager-a", map[string]string{"a": "1"})).To(Succeed())
+
+			obj := &corev1.ConfigMap{}
+			Expect(cl.Get(context.Background(), key, obj)).To(Succeed())
+			Expect(obj.ManagedFields).To(HaveLen(1))
+			Expect(obj.ManagedFields[0].Manager).To(Equal("manager-a"))
+			Expect(obj.ManagedFields[0].Operation).To(Equal(metav1.ManagedFieldsOperationApply))
+			Expect(obj.ManagedFields[0].APIVersion).To(Equal("v1"))
+			Expect(string(obj.ManagedFields[0].FieldsV1.Raw)).To(Equal(`{"f:data":{"f:a":{}}}`))
+		})
+
+		It("should report conflicts with other managers", func() {
+			Expect(applyData("manager-a", map[string]string{"a": "1"})).To(Succeed())
+
+			err := applyData("manager-b", map[string]string{"a": "2"})
+			Expect(apierrors.IsConflict(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(`conflict with "manager-a"`))
+			Expect(getData()).To(Equal(map[string]string{"a": "1"}))
+		})
+
+		It("should take ownership of conflicting fields with ForceOwnership", func() {
+			Expect(applyData("manager-a", map[string]string{"a": "1"})).To(Succeed())
+			Expect(applyData("manager-b", map[string]string{"a": "2"}, client.ForceOwnership)).To(Succeed())
+			Expect(getData()).To(Equal(map[string]string{"a": "2"}))
+
+			By("removing the field from the previous owner")
+			obj := &corev1.ConfigMap{}
+			Expect(cl.Get(context.Background(), key, obj)).To(Succeed())
+			Expect(obj.ManagedFields).To(HaveLen(1))
+			Expect(obj.ManagedFields[0].Manager).To(Equal("manager-b"))
+		})
+
+		It("should allow managers to apply the same value", func() {
+			Expect(applyData("manager-a", map[string]string{"a": "1"})).To(Succeed())
+			Expect(applyData("manager-b", map[string]string{"a": "1", "b": "2"})).To(Succeed())
+
+			By("keeping fields that are still applied by another manager")
+			Expect(applyData("manager-a", map[string]string{})).To(Succeed())
+			Expect(getData()).To(Equal(map[string]string{"a": "1", "b": "2"}))
+		})
+
+		It("should remove fields that are no longer applied", func() {
+			Expect(applyData("manager-a", map[string]string{"a": "1", "b": "2"})).To(Succeed())
+			Expect(applyData("manager-a", map[string]string{"a": "1"})).To(Succeed())
+			Expect(getData()).To(Equal(map[string]string{"a": "1"}))
+		})
+
+		It("should report conflicts with fields set by updates", func() {
+			Expect(applyData("manager-a", map[string]string{"a": "1"})).To(Succeed())
+
+			obj := &corev1.ConfigMap{}
+			Expect(cl.Get(context.Background(), key, obj)).To(Succeed())
+			obj.Data["a"] = "2"
+			Expect(cl.Update(context.Background(), obj, client.FieldOwner("updater"))).To(Succeed())
+			Expect(obj.ManagedFields).To(HaveLen(1))
+			Expect(obj.ManagedFields[0].Manager).To(Equal("updater"))
+			Expect(obj.ManagedFields[0].Operation).To(Equal(metav1.ManagedFieldsOperationUpdate))
+
+			err := applyData("manager-a", map[string]string{"a": "1"})
+			Expect(apierrors.IsConflict(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(`conflict with "updater" using v1`))
+		})
+
+		It("should merge lists by their patch merge key", func() {
+			container := func(name string) *corev1ac.ContainerApplyConfiguration {
+				return corev1ac.Container().WithName(name).WithImage(name)
+			}
+			deployment := func(containers ...*corev1ac.ContainerApplyConfiguration) *appsv1ac.DeploymentApplyConfiguration {
+				return appsv1ac.Deployment("applied", "ns1").WithSpec(appsv1ac.DeploymentSpec().
+					WithTemplate(corev1ac.PodTemplateSpec().WithSpec(corev1ac.PodSpec().WithContainers(containers...))))
+			}
+			Expect(cl.Apply(context.Background(), deployment(container("a")), client.FieldOwner("manager-a"))).To(Succeed())
+			Expect(cl.Apply(context.Background(), deployment(container("b")), client.FieldOwner("manager-b"))).To(Succeed())
+
+			obj := &appsv1.Deployment{}
+			Expect(cl.Get(context.Background(), key, obj)).To(Succeed())
+			Expect(obj.Spec.Template.Spec.Containers).To(HaveLen(2))
+
+			Expect(cl.Apply(context.Background(), deployment(), client.FieldOwner("manager-a"))).To(Succeed())
+			Expect(cl.Get(context.Background(), key, obj)).To(Succeed())
+			Expect(obj.Spec.Template.Spec.Containers).To(HaveLen(1))
+			Expect(obj.Spec.Template.Spec.Containers[0].Name).To(Equal("b"))
+		})
+
+		It("should apply with an apply patch", func() {
+			obj := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+				Data:       map[string]string{"a": "1"},
+			}
+			Expect(cl.Patch(context.Background(), obj, client.Apply, client.FieldOwner("manager-a"))).To(Succeed())
+			Expect(obj.ResourceVersion).To(Equal("1"))
+			Expect(obj.ManagedFields).To(HaveLen(1))
+			Expect(getData()).To(Equal(map[string]string{"a": "1"}))
+		})
+
+		It("should apply kinds that are not in the scheme", func() {
+			u := &unstructured.Unstructured{}
+			u.SetAPIVersion("example.com/v1")
+			u.SetKind("Widget")
+			u.SetNamespace(key.Namespace)
+			u.SetName(key.Name)
+			Expect(unstructured.SetNestedField(u.Object, "blue", "spec", "color")).To(Succeed())
+			Expect(cl.Apply(context.Background(), u, client.FieldOwner("manager-a"))).To(Succeed())
+
+			conflicting := u.DeepCopy()
+			conflicting.SetResourceVersion("")
+			Expect(unstructured.SetNestedField(conflicting.Object, "red", "spec", "color")).To(Succeed())
+			err := cl.Apply(context.Background(), conflicting, client.FieldOwner("manager-b"))
+			Expect(apierrors.IsConflict(err)).To(BeTrue())
+		})
+	})
+
+	Context("with interceptor funcs", func() {
+		It("should intercept calls and pass them through to the fake client", func() {
+			var created []string
+			cl = NewClientBuilder().
+				WithObjects(cm).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(ctx context.Context, c client.Client, obj client.Object, opts ...client.CreateOption) error {
+						created = append(created, obj.GetName())
+						return c.Create(ctx, obj, opts...)
+					},
+					StatusUpdate: func(ctx context.Context, c client.StatusWriter, obj client.Object, opts ...client.UpdateOption) error {
+						return apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, obj.GetName(), fmt.Errorf("injected"))
+					},
+				}).
+				Build()
+
+			newCM := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "new-cm", Namespace: "ns2"}}
+			Expect(cl.Create(context.Background(), newCM)).To(Succeed())
+			Expect(created).To(Equal([]string{"new-cm"}))
+			Expect(cl.Get(context.Background(), client.ObjectKeyFromObject(newCM), &corev1.ConfigMap{})).To(Succeed())
+
+			err := cl.Status().Update(context.Background(), cm)
+			Expect(apierrors.IsConflict(err)).To(BeTrue())
+		})
+	})
+
+	Context("with garbage collection", func() {
+		var owner *appsv1.Deployment
+		var dependent, grandDependent, unrelated *corev1.ConfigMap
+
+		BeforeEach(func() {
+			owner = &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "gc"}}
+			dependent = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "dependent", Namespace: "gc"}}
+			grandDependent = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "grand-dependent", Namespace: "gc"}}
+			unrelated = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "gc"}}
+
+			cl = NewClientBuilder().WithGarbageCollection().Build()
+			Expect(cl.Create(context.Background(), owner)).To(Succeed())
+			Expect(owner.UID).NotTo(BeEmpty())
+			Expect(controllerutil.SetControllerReference(owner, dependent, scheme.Scheme)).To(Succeed())
+			Expect(cl.Create(context.Background(), dependent)).To(Succeed())
+			Expect(controllerutil.SetControllerReference(dependent, grandDependent, scheme.Scheme)).To(Succeed())
+			Expect(cl.Create(context.Background(), grandDependent)).To(Succeed())
+			Expect(cl.Create(context.Background(), unrelated)).To(Succeed())
+		})
+
+		// exists gets obj again, resetting it first so that fields that were
+		// removed don't linger.
+		exists := func(obj client.Object) bool {
+			key := client.ObjectKeyFromObject(obj)
+			reflect.ValueOf(obj).Elem().Set(reflect.Zero(reflect.TypeOf(obj).Elem()))
+			obj.SetName(key.Name)
+			obj.SetNamespace(key.Namespace)
+			err := cl.Get(context.Background(), key, obj)
+			if apierrors.IsNotFound(err) {
+				return false
+			}
+			Expect(err).NotTo(HaveOccurred())
+			return true
+		}
+
+		It("should delete dependents in the background by default", func() {
+			Expect(cl.Delete(context.Background(), owner)).To(Succeed())
+			Expect(exists(owner)).To(BeFalse())
+			Expect(exists(dependent)).To(BeFalse())
+			Expect(exists(grandDependent)).To(BeFalse())
+			Expect(exists(unrelated)).To(BeTrue())
+		})
+
+		It("should keep the owner until its dependents are gone in the foreground", func() {
+			By("Adding a finalizer to the dependent")
+			controllerutil.AddFinalizer(dependent, "finalizers.sigs.k8s.io/test")
+			Expect(cl.Update(context.Background(), dependent)).To(Succeed())
+
+			Expect(cl.Delete(context.Background(), owner, client.PropagationPolicy(metav1.DeletePropagationForeground))).To(Succeed())
+			Expect(exists(grandDependent)).To(BeFalse())
+			Expect(exists(dependent)).To(BeTrue())
+			Expect(dependent.DeletionTimestamp).NotTo(BeNil())
+			Expect(exists(owner)).To(BeTrue())
+			Expect(owner.DeletionTimestamp).NotTo(BeNil())
+			Expect(owner.Finalizers).To(Equal([]string{metav1.FinalizerDeleteDependents}))
+
+			By("Removing the finalizer of the dependent")
+			controllerutil.RemoveFinalizer(dependent, "finalizers.sigs.k8s.io/test")
+			Expect(cl.Update(context.Background(), dependent)).To(Succeed())
+			Expect(exists(dependent)).To(BeFalse())
+			Expect(exists(owner)).To(BeFalse())
+			Expect(exists(unrelated)).To(BeTrue())
+		})
+
+		It("should orphan dependents", func() {
+			Expect(cl.Delete(context.Background(), owner, client.PropagationPolicy(metav1.DeletePropagationOrphan))).To(Succeed())
+			Expect(exists(owner)).To(BeFalse())
+			Expect(exists(dependent)).To(BeTrue())
+			Expect(dependent.OwnerReferences).To(BeEmpty())
+			Expect(exists(grandDependent)).To(BeTrue())
+			Expect(grandDependent.OwnerReferences).To(HaveLen(1))
+		})
+
+		It("should only remove the reference to a deleted owner if there are others", func() {
+			other := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "other-owner", Namespace: "gc"}}
+			Expect(cl.Create(context.Background(), other)).To(Succeed())
+			Expect(controllerutil.SetOwnerReference(other, dependent, scheme.Scheme)).To(Succeed())
+			Expect(cl.Update(context.Background(), dependent)).To(Succeed())
+
+			Expect(cl.Delete(context.Background(), owner)).To(Succeed())
+			Expect(exists(owner)).To(BeFalse())
+			Expect(exists(dependent)).To(BeTrue())
+			Expect(dependent.OwnerReferences).To(HaveLen(1))
+			Expect(dependent.OwnerReferences[0].UID).To(Equal(other.UID))
+			Expect(exists(grandDependent)).To(BeTrue())
+		})
+
+		It("should collect the dependents of objects deleted with DeleteAllOf", func() {
+			Expect(cl.DeleteAllOf(context.Background(), &appsv1.Deployment{}, client.InNamespace("gc"))).To(Succeed())
+			Expect(exists(dependent)).To(BeFalse())
+			Expect(exists(grandDependent)).To(BeFalse())
+		})
+
+		It("should not collect garbage unless enabled", func() {
+			cl = NewClientBuilder().WithObjects(owner, dependent).Build()
+			Expect(cl.Delete(context.Background(), owner)).To(Succeed())
+			Expect(exists(dependent)).To(BeTrue())
+		})
+	})
+
 	It("should set the ResourceVersion to 999 when adding an object to the tracker", func() {
 		cl := NewClientBuilder().WithObjects(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "cm"}}).Build()
 
@@ -24,13 +24,23 @@ You can create a fake client with optional objects.
 
 You can invoke the methods defined in the Client interface.
 
+ClientBuilder.BuildWithCache additionally returns a cache.Cache that shares the
+storage of the client, whose informers deliver events for the writes made
+through the client.  It can be used to run controllers in tests without an API
+server:
+
+	client, cache := NewClientBuilder().WithObjects(initObjs...).BuildWithCache()
+
+Errors can be injected with ClientBuilder.WithInterceptorFuncs, to test how
+handled and unhandled errors of the client are dealt with.
+
 When in doubt, it's almost always better not to use this package and instead use
 envtest.Environment with a real client and API server.
 
 WARNING: ⚠️ Current Limitations / Known Issues with the fake Client ⚠️
-- This client does not have a way to inject specific errors to test handled vs. unhandled errors.
 - There is some support for sub resources which can cause issues with tests if you're trying to update
-  e.g. metadata and status in the same reconcile.
+  e.g. metadata and status in the same reconcile.  The status is only treated as a subresource for
+  the kinds registered with ClientBuilder.WithStatusSubresource.
 - No OpeanAPI validation is performed when creating or updating objects.
 - ObjectMeta's `Generation` and `ResourceVersion` don't behave properly, Patch or Update
 operations that rely on these fields will fail, or give false positives.
@@ -0,0 +1,273 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// garbageCollector simulates the garbage collector of Kubernetes for the
+// objects of a versionedTracker.  It is enabled with
+// ClientBuilder.WithGarbageCollection.
+//
+// Unlike the real garbage collector, it runs synchronously: garbage is
+// collected right after each delete request, and after each update or patch
+// that removes an object, until there is nothing left to collect.
+type garbageCollector struct {
+	// mu serializes collections.
+	mu sync.Mutex
+
+	resourcesLock sync.Mutex
+	// resources are the resources objects have been stored for, which are
+	// the only ones that can hold dependents.
+	resources map[schema.GroupVersionResource]schema.GroupVersionKind
+}
+
+func newGarbageCollector() *garbageCollector {
+	return &garbageCollector{resources: map[schema.GroupVersionResource]schema.GroupVersionKind{}}
+}
+
+// track records that objects of the given resource are stored.
+func (gc *garbageCollector) track(gvr schema.GroupVersionResource, gvk schema.GroupVersionKind) {
+	gc.resourcesLock.Lock()
+	defer gc.resourcesLock.Unlock()
+	gc.resources[gvr] = gvk
+}
+
+// storedObject is an object of the tracker, along with its resource.
+type storedObject struct {
+	gvr schema.GroupVersionResource
+	obj runtime.Object
+	metav1.Object
+}
+
+// objects returns all the objects of the tracked resources.  Resources that
+// can't be listed, like kinds unknown to the scheme, are skipped.
+func (gc *garbageCollector) objects(t versionedTracker) ([]storedObject, error) {
+	gc.resourcesLock.Lock()
+	resources := make(map[schema.GroupVersionResource]schema.GroupVersionKind, len(gc.resources))
+	for gvr, gvk := range gc.resources {
+		resources[gvr] = gvk
+	}
+	gc.resourcesLock.Unlock()
+
+	var objs []storedObject
+	for gvr, gvk := range resources {
+		list, err := t.ObjectTracker.List(gvr, gvk, metav1.NamespaceAll)
+		if err != nil {
+			continue
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			accessor, err := meta.Accessor(item)
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, storedObject{gvr: gvr, obj: item, Object: accessor})
+		}
+	}
+	return objs, nil
+}
+
+// collect deletes the dependents of deleted objects and finishes the
+// deletion of objects deleted with the Orphan or Foreground propagation
+// policies, until there is nothing left to do.
+func (gc *garbageCollector) collect(t versionedTracker) error {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+
+	for {
+		changed, err := gc.collectOnce(t)
+		if err != nil || !changed {
+			return err
+		}
+	}
+}
+
+// collectOnce makes a single pass over the objects of the tracker, and
+// returns whether it changed any.
+func (gc *garbageCollector) collectOnce(t versionedTracker) (bool, error) {
+	objs, err := gc.objects(t)
+	if err != nil {
+		return false, err
+	}
+	existing := sets.NewString()
+	dependents := map[types.UID][]storedObject{}
+	for _, o := range objs {
+		existing.Insert(string(o.GetUID()))
+		for _, ref := range o.GetOwnerReferences() {
+			dependents[ref.UID] = append(dependents[ref.UID], o)
+		}
+	}
+
+	for _, o := range objs {
+		deleting := o.GetDeletionTimestamp() != nil
+		switch {
+		case deleting && hasFinalizer(o, metav1.FinalizerOrphanDependents):
+			// Orphan the dependents before letting the owner go.
+			for _, dependent := range dependents[o.GetUID()] {
+				if err := t.removeOwnerReferences(dependent, sets.NewString(string(o.GetUID()))); err != nil {
+					return false, err
+				}
+			}
+			return true, t.removeFinalizer(o, metav1.FinalizerOrphanDependents)
+
+		case deleting && hasFinalizer(o, metav1.FinalizerDeleteDependents):
+			// Delete the dependents, and let the owner go once they are
+			// all gone.
+			if len(dependents[o.GetUID()]) == 0 {
+				return true, t.removeFinalizer(o, metav1.FinalizerDeleteDependents)
+			}
+			foreground := metav1.DeletePropagationForeground
+			changed := false
+			for _, dependent := range dependents[o.GetUID()] {
+				if dependent.GetDeletionTimestamp() != nil {
+					continue
+				}
+				if err := t.deleteObject(dependent.gvr, dependent.GetNamespace(), dependent.GetName(), &foreground); err != nil {
+					return false, err
+				}
+				changed = true
+			}
+			if changed {
+				return true, nil
+			}
+		}
+
+		// Delete objects whose owners are all gone, and remove the
+		// references to owners that are gone from the others.
+		dangling := sets.NewString()
+		for _, ref := range o.GetOwnerReferences() {
+			if ref.UID != "" && !existing.Has(string(ref.UID)) {
+				dangling.Insert(string(ref.UID))
+			}
+		}
+		switch {
+		case dangling.Len() == 0:
+		case dangling.Len() == len(o.GetOwnerReferences()):
+			if deleting {
+				continue
+			}
+			background := metav1.DeletePropagationBackground
+			return true, t.deleteObject(o.gvr, o.GetNamespace(), o.GetName(), &background)
+		default:
+			return true, t.removeOwnerReferences(o, dangling)
+		}
+	}
+	return false, nil
+}
+
+// deleteObject deletes an object, or marks it as being deleted if it has
+// finalizers.  When the garbage collector is enabled, the propagation policy
+// defaults to Background; the Orphan and Foreground policies add the
+// corresponding finalizer to the object, like the API server does.
+func (t versionedTracker) deleteObject(gvr schema.GroupVersionResource, ns, name string, policy *metav1.DeletionPropagation) error {
+	obj, err := t.ObjectTracker.Get(gvr, ns, name)
+	if err != nil {
+		return err
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	if accessor.GetDeletionTimestamp() != nil {
+		// The object is already being deleted.
+		return nil
+	}
+
+	if t.gc != nil && policy != nil {
+		switch *policy {
+		case metav1.DeletePropagationOrphan:
+			addFinalizer(accessor, metav1.FinalizerOrphanDependents)
+		case metav1.DeletePropagationForeground:
+			addFinalizer(accessor, metav1.FinalizerDeleteDependents)
+		case metav1.DeletePropagationBackground:
+		default:
+			return apierrors.NewBadRequest("unsupported propagation policy " + string(*policy))
+		}
+	}
+	if len(accessor.GetFinalizers()) > 0 {
+		// Like the API server, store the timestamp with the precision it
+		// is serialized with.
+		now := metav1.Now().Rfc3339Copy()
+		accessor.SetDeletionTimestamp(&now)
+		return t.update(gvr, obj, ns, writeOptions{deleting: true, collecting: true})
+	}
+	return t.ObjectTracker.Delete(gvr, ns, name)
+}
+
+// removeFinalizer removes a finalizer from a stored object, which deletes
+// it if it is being deleted and this was its last finalizer.
+func (t versionedTracker) removeFinalizer(o storedObject, finalizer string) error {
+	obj := o.obj.DeepCopyObject()
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	var finalizers []string
+	for _, f := range accessor.GetFinalizers() {
+		if f != finalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	accessor.SetFinalizers(finalizers)
+	return t.update(o.gvr, obj, o.GetNamespace(), writeOptions{collecting: true})
+}
+
+// removeOwnerReferences removes the references to the owners with the given
+// UIDs from a stored object.
+func (t versionedTracker) removeOwnerReferences(o storedObject, uids sets.String) error {
+	obj := o.obj.DeepCopyObject()
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	var refs []metav1.OwnerReference
+	for _, ref := range accessor.GetOwnerReferences() {
+		if !uids.Has(string(ref.UID)) {
+			refs = append(refs, ref)
+		}
+	}
+	accessor.SetOwnerReferences(refs)
+	return t.update(o.gvr, obj, o.GetNamespace(), writeOptions{collecting: true})
+}
+
+func hasFinalizer(obj metav1.Object, finalizer string) bool {
+	for _, f := range obj.GetFinalizers() {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+func addFinalizer(obj metav1.Object, finalizer string) {
+	if !hasFinalizer(obj, finalizer) {
+		obj.SetFinalizers(append(obj.GetFinalizers(), finalizer))
+	}
+}
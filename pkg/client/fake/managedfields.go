@@ -0,0 +1,346 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v4/merge"
+	"sigs.k8s.io/structured-merge-diff/v4/typed"
+)
+
+// defaultFieldManager is the manager of fields changed by non-apply requests
+// without a field manager, on objects whose fields are managed.
+const defaultFieldManager = "fake-client"
+
+// fieldsTypeV1 is the only supported format of managed fields.
+const fieldsTypeV1 = "FieldsV1"
+
+// strippedFields are never part of the fields owned by a manager, like in
+// the API server.
+var strippedFields = fieldpath.NewSet(
+	fieldpath.MakePathOrDie("apiVersion"),
+	fieldpath.MakePathOrDie("kind"),
+	fieldpath.MakePathOrDie("metadata"),
+	fieldpath.MakePathOrDie("metadata", "name"),
+	fieldpath.MakePathOrDie("metadata", "namespace"),
+	fieldpath.MakePathOrDie("metadata", "creationTimestamp"),
+	fieldpath.MakePathOrDie("metadata", "selfLink"),
+	fieldpath.MakePathOrDie("metadata", "uid"),
+	fieldpath.MakePathOrDie("metadata", "clusterName"),
+	fieldpath.MakePathOrDie("metadata", "generation"),
+	fieldpath.MakePathOrDie("metadata", "managedFields"),
+	fieldpath.MakePathOrDie("metadata", "resourceVersion"),
+)
+
+// fieldManager implements server-side apply and keeps track of the managed
+// fields of objects, using structured-merge-diff like the API server.
+type fieldManager struct {
+	converter *typeConverter
+	updater   merge.Updater
+}
+
+func newFieldManager(scheme *runtime.Scheme) *fieldManager {
+	return &fieldManager{
+		converter: newTypeConverter(scheme),
+		updater:   merge.Updater{Converter: versionConverter{}},
+	}
+}
+
+// update records the fields changed from live to obj as owned by manager in
+// the managed fields of obj.  live is nil for create requests.  Nothing is
+// recorded if manager is empty and the fields of the object aren't managed.
+func (f *fieldManager) update(gvk schema.GroupVersionKind, live, obj runtime.Object, manager string) error {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	liveContent := map[string]interface{}{}
+	if live != nil {
+		liveAccessor, err := meta.Accessor(live)
+		if err != nil {
+			return err
+		}
+		// Like the API server, keep the managed fields of the live object
+		// if the request doesn't set any.
+		if accessor.GetManagedFields() == nil {
+			accessor.SetManagedFields(liveAccessor.GetManagedFields())
+		}
+		if liveContent, err = objectContent(live); err != nil {
+			return err
+		}
+	}
+	if manager == "" {
+		if len(accessor.GetManagedFields()) == 0 {
+			return nil
+		}
+		manager = defaultFieldManager
+	}
+
+	managed, err := decodeManagedFields(accessor.GetManagedFields())
+	if err != nil {
+		return err
+	}
+	content, err := objectContent(obj)
+	if err != nil {
+		return err
+	}
+	values, err := f.converter.toTyped(gvk, liveContent, content)
+	if err != nil {
+		return err
+	}
+	key, err := managerIdentifier(manager, metav1.ManagedFieldsOperationUpdate, gvk.GroupVersion().String())
+	if err != nil {
+		return err
+	}
+	_, managed.fields, err = f.updater.Update(values[0], values[1], fieldpath.APIVersion(gvk.GroupVersion().String()), managed.fields, key)
+	if err != nil {
+		return err
+	}
+	managed.touch(key)
+
+	entries, err := managed.encode()
+	if err != nil {
+		return err
+	}
+	accessor.SetManagedFields(entries)
+	return nil
+}
+
+// apply applies config to live on behalf of manager, and returns the
+// resulting object including its managed fields.  live is nil if the object
+// doesn't exist yet.  Conflicts with other managers are returned as an
+// error, unless force is set.
+func (f *fieldManager) apply(gvk schema.GroupVersionKind, live runtime.Object, config *unstructured.Unstructured, manager string, force bool) (*unstructured.Unstructured, error) {
+	liveObj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	var liveManagedFields []metav1.ManagedFieldsEntry
+	if live != nil {
+		liveAccessor, err := meta.Accessor(live)
+		if err != nil {
+			return nil, err
+		}
+		liveManagedFields = liveAccessor.GetManagedFields()
+		if liveObj.Object, err = objectContent(live); err != nil {
+			return nil, err
+		}
+	}
+	if rv := config.GetResourceVersion(); rv != "" && rv != liveObj.GetResourceVersion() {
+		gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+		return nil, apierrors.NewConflict(gvr.GroupResource(), config.GetName(), errors.New("object was modified"))
+	}
+
+	managed, err := decodeManagedFields(liveManagedFields)
+	if err != nil {
+		return nil, err
+	}
+	resourceVersion := liveObj.GetResourceVersion()
+	configObj := config.DeepCopy()
+	configObj.SetManagedFields(nil)
+	configObj.SetResourceVersion("")
+
+	values, err := f.converter.toTyped(gvk, liveObj.Object, configObj.Object)
+	if err != nil {
+		return nil, err
+	}
+	key, err := managerIdentifier(manager, metav1.ManagedFieldsOperationApply, "")
+	if err != nil {
+		return nil, err
+	}
+	applied, fields, err := f.updater.Apply(values[0], values[1], fieldpath.APIVersion(gvk.GroupVersion().String()), managed.fields, key, force)
+	if err != nil {
+		var conflicts merge.Conflicts
+		if errors.As(err, &conflicts) {
+			return nil, newApplyConflictError(conflicts)
+		}
+		return nil, err
+	}
+	managed.fields = fields
+	managed.touch(key)
+	if applied == nil {
+		applied = values[0]
+	}
+
+	content, isMap := applied.AsValue().Unstructured().(map[string]interface{})
+	if !isMap {
+		return nil, fmt.Errorf("applying %v resulted in %T, expected an object", gvk, applied.AsValue().Unstructured())
+	}
+	result := &unstructured.Unstructured{Object: content}
+	result.SetGroupVersionKind(gvk)
+	result.SetNamespace(config.GetNamespace())
+	result.SetName(config.GetName())
+	result.SetResourceVersion(resourceVersion)
+	entries, err := managed.encode()
+	if err != nil {
+		return nil, err
+	}
+	result.SetManagedFields(entries)
+	return result, nil
+}
+
+// objectContent returns a copy of the content of obj, without its managed
+// fields.
+func objectContent(obj runtime.Object) (map[string]interface{}, error) {
+	var content map[string]interface{}
+	if u, isUnstructured := obj.(runtime.Unstructured); isUnstructured {
+		content = runtime.DeepCopyJSON(u.UnstructuredContent())
+	} else {
+		var err error
+		if content, err = runtime.DefaultUnstructuredConverter.ToUnstructured(obj); err != nil {
+			return nil, err
+		}
+	}
+	unstructured.RemoveNestedField(content, "metadata", "managedFields")
+	return content, nil
+}
+
+// managedFields are the decoded managed fields of an object.
+type managedFields struct {
+	fields fieldpath.ManagedFields
+	times  map[string]*metav1.Time
+}
+
+func decodeManagedFields(entries []metav1.ManagedFieldsEntry) (*managedFields, error) {
+	managed := &managedFields{
+		fields: fieldpath.ManagedFields{},
+		times:  map[string]*metav1.Time{},
+	}
+	for _, entry := range entries {
+		if entry.FieldsType != fieldsTypeV1 {
+			return nil, fmt.Errorf("unsupported managed fields type %q of manager %q", entry.FieldsType, entry.Manager)
+		}
+		key, err := managerIdentifier(entry.Manager, entry.Operation, entry.APIVersion)
+		if err != nil {
+			return nil, err
+		}
+		set := &fieldpath.Set{}
+		if entry.FieldsV1 != nil {
+			if err := set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+				return nil, fmt.Errorf("invalid managed fields of manager %q: %w", entry.Manager, err)
+			}
+		}
+		applied := entry.Operation == metav1.ManagedFieldsOperationApply
+		managed.fields[key] = fieldpath.NewVersionedSet(set, fieldpath.APIVersion(entry.APIVersion), applied)
+		managed.times[key] = entry.Time
+	}
+	return managed, nil
+}
+
+// touch sets the time of the given manager's last change to now.
+func (m *managedFields) touch(key string) {
+	now := metav1.Now()
+	m.times[key] = &now
+}
+
+// encode returns the managed fields entries, without the fields that are
+// never owned by any manager.
+func (m *managedFields) encode() ([]metav1.ManagedFieldsEntry, error) {
+	var entries []metav1.ManagedFieldsEntry
+	for key, versioned := range m.fields {
+		set := versioned.Set().Difference(strippedFields)
+		if set.Empty() {
+			continue
+		}
+		entry := metav1.ManagedFieldsEntry{}
+		if err := json.Unmarshal([]byte(key), &entry); err != nil {
+			return nil, err
+		}
+		raw, err := set.ToJSON()
+		if err != nil {
+			return nil, err
+		}
+		entry.APIVersion = string(versioned.APIVersion())
+		entry.FieldsType = fieldsTypeV1
+		entry.FieldsV1 = &metav1.FieldsV1{Raw: raw}
+		entry.Time = m.times[key]
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].Time.Equal(entries[j].Time) {
+			return entries[j].Time == nil || (entries[i].Time != nil && entries[i].Time.Before(entries[j].Time))
+		}
+		if entries[i].Manager != entries[j].Manager {
+			return entries[i].Manager < entries[j].Manager
+		}
+		return entries[i].Operation < entries[j].Operation
+	})
+	return entries, nil
+}
+
+// managerIdentifier returns the key of a manager in fieldpath.ManagedFields.
+// Like in the API server, appliers are identified by their name only, and
+// other managers by their name and API version.
+func managerIdentifier(manager string, operation metav1.ManagedFieldsOperationType, apiVersion string) (string, error) {
+	entry := metav1.ManagedFieldsEntry{Manager: manager, Operation: operation}
+	if operation == metav1.ManagedFieldsOperationUpdate {
+		entry.APIVersion = apiVersion
+	}
+	key, err := json.Marshal(&entry)
+	if err != nil {
+		return "", err
+	}
+	return string(key), nil
+}
+
+// newApplyConflictError returns the error the API server returns for
+// conflicting apply requests.
+func newApplyConflictError(conflicts merge.Conflicts) error {
+	causes := make([]metav1.StatusCause, 0, len(conflicts))
+	messages := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		entry := metav1.ManagedFieldsEntry{}
+		manager := conflict.Manager
+		if err := json.Unmarshal([]byte(conflict.Manager), &entry); err == nil {
+			manager = fmt.Sprintf("%q", entry.Manager)
+			if entry.Operation == metav1.ManagedFieldsOperationUpdate {
+				manager = fmt.Sprintf("%s using %s", manager, entry.APIVersion)
+			}
+		}
+		cause := metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: fmt.Sprintf("conflict with %s", manager),
+			Field:   conflict.Path.String(),
+		}
+		causes = append(causes, cause)
+		messages = append(messages, fmt.Sprintf("%s: %s", cause.Message, cause.Field))
+	}
+	return apierrors.NewApplyConflict(causes, fmt.Sprintf("Apply failed with %d conflict(s): %s", len(conflicts), strings.Join(messages, ", ")))
+}
+
+// versionConverter is a merge.Converter for objects whose versions are never
+// converted: the fake client stores objects in the version they were
+// written with.
+type versionConverter struct{}
+
+func (versionConverter) Convert(object *typed.TypedValue, _ fieldpath.APIVersion) (*typed.TypedValue, error) {
+	return object, nil
+}
+
+func (versionConverter) IsMissingVersionError(error) bool {
+	return false
+}
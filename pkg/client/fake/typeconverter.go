@@ -0,0 +1,249 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	smdschema "sigs.k8s.io/structured-merge-diff/v4/schema"
+	"sigs.k8s.io/structured-merge-diff/v4/typed"
+)
+
+const (
+	untypedAtomicName  = "__untyped_atomic_"
+	untypedDeducedName = "__untyped_deduced_"
+)
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// typeConverter converts objects to the typed values used by
+// structured-merge-diff.  The schema of each kind is derived from the Go type
+// registered for it in the scheme: lists tagged with the "merge" patch
+// strategy are associative, keyed by their patch merge key, and all other
+// lists are atomic.  Kinds that aren't registered in the scheme, as well as
+// objects that don't match the derived schema, use a schema deduced from the
+// object itself.
+type typeConverter struct {
+	scheme *runtime.Scheme
+
+	mu    sync.Mutex
+	types map[schema.GroupVersionKind]typed.ParseableType
+}
+
+func newTypeConverter(scheme *runtime.Scheme) *typeConverter {
+	return &typeConverter{
+		scheme: scheme,
+		types:  map[schema.GroupVersionKind]typed.ParseableType{},
+	}
+}
+
+// toTyped converts the given object contents, all of the given kind, to typed
+// values that share the same schema.
+func (c *typeConverter) toTyped(gvk schema.GroupVersionKind, contents ...map[string]interface{}) ([]*typed.TypedValue, error) {
+	values, err := parseAll(c.parseableType(gvk), contents)
+	if err != nil {
+		return parseAll(typed.DeducedParseableType, contents)
+	}
+	return values, nil
+}
+
+func parseAll(pt typed.ParseableType, contents []map[string]interface{}) ([]*typed.TypedValue, error) {
+	values := make([]*typed.TypedValue, 0, len(contents))
+	for _, content := range contents {
+		tv, err := pt.FromUnstructured(content)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, tv)
+	}
+	return values, nil
+}
+
+func (c *typeConverter) parseableType(gvk schema.GroupVersionKind) typed.ParseableType {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if pt, ok := c.types[gvk]; ok {
+		return pt
+	}
+
+	pt := typed.DeducedParseableType
+	if obj, err := c.scheme.New(gvk); err == nil {
+		if _, isUnstructured := obj.(runtime.Unstructured); !isUnstructured {
+			b := &schemaBuilder{names: map[reflect.Type]string{}}
+			ref := b.typeRef(reflect.TypeOf(obj))
+			pt = typed.ParseableType{
+				Schema:  &smdschema.Schema{Types: append(b.types, untypedTypeDefs()...)},
+				TypeRef: ref,
+			}
+		}
+	}
+	c.types[gvk] = pt
+	return pt
+}
+
+// schemaBuilder derives a structured-merge-diff schema from Go types.
+type schemaBuilder struct {
+	types []smdschema.TypeDef
+	names map[reflect.Type]string
+}
+
+func (b *schemaBuilder) typeRef(t reflect.Type) smdschema.TypeRef {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	// Types with their own serialization, like metav1.Time or
+	// resource.Quantity, can't be described from their Go fields.
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+		return namedTypeRef(untypedDeducedName)
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return scalarTypeRef(smdschema.String)
+	case reflect.Bool:
+		return scalarTypeRef(smdschema.Boolean)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return scalarTypeRef(smdschema.Numeric)
+	case reflect.Map:
+		return smdschema.TypeRef{Inlined: smdschema.Atom{Map: &smdschema.Map{
+			ElementType:         b.typeRef(t.Elem()),
+			ElementRelationship: smdschema.Separable,
+		}}}
+	case reflect.Slice, reflect.Array:
+		return b.listTypeRef(t, "", "")
+	case reflect.Struct:
+		return b.structTypeRef(t)
+	default:
+		return namedTypeRef(untypedDeducedName)
+	}
+}
+
+// listTypeRef returns the type of a list, given the patch strategy and merge
+// key of the struct field holding it.
+func (b *schemaBuilder) listTypeRef(t reflect.Type, patchStrategy, patchMergeKey string) smdschema.TypeRef {
+	if t.Elem().Kind() == reflect.Uint8 {
+		// []byte is serialized as a base64 encoded string.
+		return scalarTypeRef(smdschema.String)
+	}
+	elem := b.typeRef(t.Elem())
+	list := &smdschema.List{ElementType: elem, ElementRelationship: smdschema.Atomic}
+	if strings.Contains(patchStrategy, "merge") {
+		switch {
+		case patchMergeKey != "":
+			list.ElementRelationship = smdschema.Associative
+			list.Keys = []string{patchMergeKey}
+		case elem.Inlined.Scalar != nil:
+			list.ElementRelationship = smdschema.Associative
+		}
+	}
+	return smdschema.TypeRef{Inlined: smdschema.Atom{List: list}}
+}
+
+func (b *schemaBuilder) structTypeRef(t reflect.Type) smdschema.TypeRef {
+	if t.Name() == "" {
+		return smdschema.TypeRef{Inlined: smdschema.Atom{Map: b.structMap(t)}}
+	}
+	name, known := b.names[t]
+	if !known {
+		name = t.PkgPath() + "." + t.Name()
+		b.names[t] = name
+		// Register the type before building it, so that recursive types
+		// refer to themselves.
+		idx := len(b.types)
+		b.types = append(b.types, smdschema.TypeDef{Name: name})
+		m := b.structMap(t)
+		b.types[idx].Atom = smdschema.Atom{Map: m}
+	}
+	return namedTypeRef(name)
+}
+
+func (b *schemaBuilder) structMap(t reflect.Type) *smdschema.Map {
+	m := &smdschema.Map{}
+	b.addFields(m, t)
+	return m
+}
+
+func (b *schemaBuilder) addFields(m *smdschema.Map, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			b.addFields(m, ft)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		var ref smdschema.TypeRef
+		if ft.Kind() == reflect.Slice && !reflect.PtrTo(ft).Implements(jsonMarshalerType) {
+			ref = b.listTypeRef(ft, f.Tag.Get("patchStrategy"), f.Tag.Get("patchMergeKey"))
+		} else {
+			ref = b.typeRef(ft)
+		}
+		m.Fields = append(m.Fields, smdschema.StructField{Name: name, Type: ref})
+	}
+}
+
+func namedTypeRef(name string) smdschema.TypeRef {
+	return smdschema.TypeRef{NamedType: &name}
+}
+
+func scalarTypeRef(scalar smdschema.Scalar) smdschema.TypeRef {
+	return smdschema.TypeRef{Inlined: smdschema.Atom{Scalar: &scalar}}
+}
+
+// untypedTypeDefs returns the types used for values without type
+// information, the same as in typed.DeducedParseableType.
+func untypedTypeDefs() []smdschema.TypeDef {
+	untyped := smdschema.Scalar("untyped")
+	return []smdschema.TypeDef{
+		{
+			Name: untypedAtomicName,
+			Atom: smdschema.Atom{
+				Scalar: &untyped,
+				List:   &smdschema.List{ElementType: namedTypeRef(untypedAtomicName), ElementRelationship: smdschema.Atomic},
+				Map:    &smdschema.Map{ElementType: namedTypeRef(untypedAtomicName), ElementRelationship: smdschema.Atomic},
+			},
+		},
+		{
+			Name: untypedDeducedName,
+			Atom: smdschema.Atom{
+				Scalar: &untyped,
+				List:   &smdschema.List{ElementType: namedTypeRef(untypedAtomicName), ElementRelationship: smdschema.Atomic},
+				Map:    &smdschema.Map{ElementType: namedTypeRef(untypedDeducedName), ElementRelationship: smdschema.Separable},
+			},
+		},
+	}
+}
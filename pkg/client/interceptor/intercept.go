@@ -0,0 +1,206 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package interceptor provides a client.Client that calls user provided
+// functions instead of, or around, the calls of another client.  It is mostly
+// useful in tests, e.g. to inject errors into a fake client.
+package interceptor
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Funcs contains functions that are called instead of the corresponding
+// calls of the underlying client.  Each function gets the underlying client,
+// so that it can pass the call through, before or after doing something else.
+// Calls without a function are passed through unmodified.
+type Funcs struct {
+	Get         func(ctx context.Context, client client.Client, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error
+	List        func(ctx context.Context, client client.Client, list client.ObjectList, opts ...client.ListOption) error
+	Create      func(ctx context.Context, client client.Client, obj client.Object, opts ...client.CreateOption) error
+	Delete      func(ctx context.Context, client client.Client, obj client.Object, opts ...client.DeleteOption) error
+	DeleteAllOf func(ctx context.Context, client client.Client, obj client.Object, opts ...client.DeleteAllOfOption) error
+	Update      func(ctx context.Context, client client.Client, obj client.Object, opts ...client.UpdateOption) error
+	Patch       func(ctx context.Context, client client.Client, obj client.Object, patch client.Patch, opts ...client.PatchOption) error
+	Apply       func(ctx context.Context, client client.Client, obj client.ApplyConfiguration, opts ...client.ApplyOption) error
+
+	StatusUpdate func(ctx context.Context, client client.StatusWriter, obj client.Object, opts ...client.UpdateOption) error
+	StatusPatch  func(ctx context.Context, client client.StatusWriter, obj client.Object, patch client.Patch, opts ...client.PatchOption) error
+	StatusApply  func(ctx context.Context, client client.StatusWriter, obj client.ApplyConfiguration, opts ...client.ApplyOption) error
+
+	SubResourceGet    func(ctx context.Context, client client.SubResourceClient, subResourceName string, obj client.Object, subResource client.Object) error
+	SubResourceCreate func(ctx context.Context, client client.SubResourceClient, subResourceName string, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error
+	SubResourceUpdate func(ctx context.Context, client client.SubResourceClient, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error
+	SubResourcePatch  func(ctx context.Context, client client.SubResourceClient, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error
+}
+
+// NewClient returns a new interceptor client that calls the functions in
+// funcs instead of the underlying client's methods, if they are not nil.
+func NewClient(interceptedClient client.Client, funcs Funcs) client.Client {
+	return &interceptor{client: interceptedClient, funcs: funcs}
+}
+
+type interceptor struct {
+	client client.Client
+	funcs  Funcs
+}
+
+var _ client.Client = &interceptor{}
+
+func (c *interceptor) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if c.funcs.Get != nil {
+		return c.funcs.Get(ctx, c.client, key, obj, opts...)
+	}
+	return c.client.Get(ctx, key, obj, opts...)
+}
+
+func (c *interceptor) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if c.funcs.List != nil {
+		return c.funcs.List(ctx, c.client, list, opts...)
+	}
+	return c.client.List(ctx, list, opts...)
+}
+
+func (c *interceptor) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if c.funcs.Create != nil {
+		return c.funcs.Create(ctx, c.client, obj, opts...)
+	}
+	return c.client.Create(ctx, obj, opts...)
+}
+
+func (c *interceptor) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if c.funcs.Delete != nil {
+		return c.funcs.Delete(ctx, c.client, obj, opts...)
+	}
+	return c.client.Delete(ctx, obj, opts...)
+}
+
+func (c *interceptor) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	if c.funcs.DeleteAllOf != nil {
+		return c.funcs.DeleteAllOf(ctx, c.client, obj, opts...)
+	}
+	return c.client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *interceptor) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if c.funcs.Update != nil {
+		return c.funcs.Update(ctx, c.client, obj, opts...)
+	}
+	return c.client.Update(ctx, obj, opts...)
+}
+
+func (c *interceptor) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if c.funcs.Patch != nil {
+		return c.funcs.Patch(ctx, c.client, obj, patch, opts...)
+	}
+	return c.client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *interceptor) Apply(ctx context.Context, obj client.ApplyConfiguration, opts ...client.ApplyOption) error {
+	if c.funcs.Apply != nil {
+		return c.funcs.Apply(ctx, c.client, obj, opts...)
+	}
+	return c.client.Apply(ctx, obj, opts...)
+}
+
+func (c *interceptor) Status() client.StatusWriter {
+	return &statusWriterInterceptor{statusWriter: c.client.Status(), funcs: c.funcs}
+}
+
+func (c *interceptor) SubResource(subResource string) client.SubResourceClient {
+	return &subResourceInterceptor{
+		subResourceName: subResource,
+		client:          c.client.SubResource(subResource),
+		funcs:           c.funcs,
+	}
+}
+
+func (c *interceptor) Scheme() *runtime.Scheme {
+	return c.client.Scheme()
+}
+
+func (c *interceptor) RESTMapper() meta.RESTMapper {
+	return c.client.RESTMapper()
+}
+
+type statusWriterInterceptor struct {
+	statusWriter client.StatusWriter
+	funcs        Funcs
+}
+
+var _ client.StatusWriter = &statusWriterInterceptor{}
+
+func (s *statusWriterInterceptor) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if s.funcs.StatusUpdate != nil {
+		return s.funcs.StatusUpdate(ctx, s.statusWriter, obj, opts...)
+	}
+	return s.statusWriter.Update(ctx, obj, opts...)
+}
+
+func (s *statusWriterInterceptor) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if s.funcs.StatusPatch != nil {
+		return s.funcs.StatusPatch(ctx, s.statusWriter, obj, patch, opts...)
+	}
+	return s.statusWriter.Patch(ctx, obj, patch, opts...)
+}
+
+func (s *statusWriterInterceptor) Apply(ctx context.Context, obj client.ApplyConfiguration, opts ...client.ApplyOption) error {
+	if s.funcs.StatusApply != nil {
+		return s.funcs.StatusApply(ctx, s.statusWriter, obj, opts...)
+	}
+	return s.statusWriter.Apply(ctx, obj, opts...)
+}
+
+type subResourceInterceptor struct {
+	subResourceName string
+	client          client.SubResourceClient
+	funcs           Funcs
+}
+
+var _ client.SubResourceClient = &subResourceInterceptor{}
+
+func (s *subResourceInterceptor) Get(ctx context.Context, obj client.Object, subResource client.Object) error {
+	if s.funcs.SubResourceGet != nil {
+		return s.funcs.SubResourceGet(ctx, s.client, s.subResourceName, obj, subResource)
+	}
+	return s.client.Get(ctx, obj, subResource)
+}
+
+func (s *subResourceInterceptor) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	if s.funcs.SubResourceCreate != nil {
+		return s.funcs.SubResourceCreate(ctx, s.client, s.subResourceName, obj, subResource, opts...)
+	}
+	return s.client.Create(ctx, obj, subResource, opts...)
+}
+
+func (s *subResourceInterceptor) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	if s.funcs.SubResourceUpdate != nil {
+		return s.funcs.SubResourceUpdate(ctx, s.client, s.subResourceName, obj, opts...)
+	}
+	return s.client.Update(ctx, obj, opts...)
+}
+
+func (s *subResourceInterceptor) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	if s.funcs.SubResourcePatch != nil {
+		return s.funcs.SubResourcePatch(ctx, s.client, s.subResourceName, obj, patch, opts...)
+	}
+	return s.client.Patch(ctx, obj, patch, opts...)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interceptor
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("NewClient", func() {
+	var (
+		underlying *recordingClient
+		cm         *corev1.ConfigMap
+	)
+
+	BeforeEach(func() {
+		underlying = &recordingClient{}
+		cm = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cm"}}
+	})
+
+	It("should pass calls without a function through", func() {
+		c := NewClient(underlying, Funcs{})
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(cm), cm)).To(Succeed())
+		Expect(c.Create(context.Background(), cm)).To(Succeed())
+		Expect(c.Status().Update(context.Background(), cm)).To(Succeed())
+		Expect(c.SubResource("scale").Get(context.Background(), cm, cm)).To(Succeed())
+		Expect(underlying.calls).To(Equal([]string{"Get", "Create", "StatusUpdate", "SubResourceGet"}))
+	})
+
+	It("should call the functions instead of the underlying client", func() {
+		conflict := apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, cm.Name, nil)
+		c := NewClient(underlying, Funcs{
+			Update: func(ctx context.Context, client client.Client, obj client.Object, opts ...client.UpdateOption) error {
+				return conflict
+			},
+			StatusPatch: func(ctx context.Context, client client.StatusWriter, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				return conflict
+			},
+		})
+		Expect(c.Update(context.Background(), cm)).To(MatchError(conflict))
+		Expect(c.Status().Patch(context.Background(), cm, client.MergeFrom(cm))).To(MatchError(conflict))
+		Expect(underlying.calls).To(BeEmpty())
+	})
+
+	It("should give the functions the underlying client", func() {
+		c := NewClient(underlying, Funcs{
+			Delete: func(ctx context.Context, client client.Client, obj client.Object, opts ...client.DeleteOption) error {
+				if err := client.Get(ctx, types.NamespacedName{}, obj); err != nil {
+					return err
+				}
+				return client.Delete(ctx, obj, opts...)
+			},
+			SubResourceUpdate: func(ctx context.Context, client client.SubResourceClient, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				Expect(subResourceName).To(Equal("scale"))
+				return client.Update(ctx, obj, opts...)
+			},
+		})
+		Expect(c.Delete(context.Background(), cm)).To(Succeed())
+		Expect(c.SubResource("scale").Update(context.Background(), cm)).To(Succeed())
+		Expect(underlying.calls).To(Equal([]string{"Get", "Delete", "SubResourceUpdate"}))
+	})
+})
+
+// recordingClient is a client.Client that records the names of the methods
+// called on it.
+type recordingClient struct {
+	client.Client
+	calls []string
+}
+
+func (c *recordingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	c.calls = append(c.calls, "Get")
+	return nil
+}
+
+func (c *recordingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	c.calls = append(c.calls, "Create")
+	return nil
+}
+
+func (c *recordingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	c.calls = append(c.calls, "Delete")
+	return nil
+}
+
+func (c *recordingClient) Status() client.StatusWriter {
+	return &recordingStatusWriter{client: c}
+}
+
+func (c *recordingClient) SubResource(subResource string) client.SubResourceClient {
+	return &recordingSubResourceClient{client: c}
+}
+
+type recordingStatusWriter struct {
+	client.StatusWriter
+	client *recordingClient
+}
+
+func (s *recordingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	s.client.calls = append(s.client.calls, "StatusUpdate")
+	return nil
+}
+
+type recordingSubResourceClient struct {
+	client.SubResourceClient
+	client *recordingClient
+}
+
+func (s *recordingSubResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object) error {
+	s.client.calls = append(s.client.calls, "SubResourceGet")
+	return nil
+}
+
+func (s *recordingSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	s.client.calls = append(s.client.calls, "SubResourceUpdate")
+	return nil
+}
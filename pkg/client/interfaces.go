@@ -42,6 +42,25 @@ type Patch interface {
 	Data(obj Object) ([]byte, error)
 }
 
+// ApplyConfiguration is a partial, declarative representation of an object
+// that is submitted with server-side apply, such as the types generated in
+// k8s.io/client-go/applyconfigurations.  It must serialize to JSON and include
+// apiVersion, kind and metadata.name (and metadata.namespace for namespaced
+// objects).  *unstructured.Unstructured and *metav1.PartialObjectMetadata
+// can also be used as apply configurations.
+//
+// Other values, such as apply configurations and typed objects, are
+// serialized to JSON.  Typed objects (runtime.Objects) are stripped of the
+// unset fields they always serialize, a null metadata.creationTimestamp
+// (also in spec.template) and an empty status, so that they aren't applied.
+// Other fields with zero values serialized without omitempty are still
+// applied, so prefer apply configurations to typed objects.  Apply
+// configurations and unstructured objects are applied as is.
+//
+// This is an empty interface because the apply configurations generated in
+// k8s.io/client-go don't implement a common marker method.
+type ApplyConfiguration interface{}
+
 // TODO(directxman12): is there a sane way to deal with get/delete options?
 
 // Reader knows how to read and list Kubernetes objects.
@@ -49,7 +68,7 @@ type Reader interface {
 	// Get retrieves an obj for the given object key from the Kubernetes Cluster.
 	// obj must be a struct pointer so that obj can be updated with the response
 	// returned by the Server.
-	Get(ctx context.Context, key ObjectKey, obj Object) error
+	Get(ctx context.Context, key ObjectKey, obj Object, opts ...GetOption) error
 
 	// List retrieves list of objects for a given namespace and list options. On a
 	// successful call, Items field in the list will be populated with the
@@ -75,6 +94,12 @@ type Writer interface {
 
 	// DeleteAllOf deletes all objects of the given type matching the given options.
 	DeleteAllOf(ctx context.Context, obj Object, opts ...DeleteAllOfOption) error
+
+	// Apply applies the given apply configuration to the Kubernetes cluster
+	// using server-side apply.  A field manager must be set with FieldOwner.
+	// obj must be a pointer so that it can be updated with the merged object
+	// returned by the Server.
+	Apply(ctx context.Context, obj ApplyConfiguration, opts ...ApplyOption) error
 }
 
 // StatusClient knows how to create a client which can update status subresource
@@ -94,6 +119,58 @@ type StatusWriter interface {
 	// pointer so that obj can be updated with the content returned by the
 	// Server.
 	Patch(ctx context.Context, obj Object, patch Patch, opts ...PatchOption) error
+
+	// Apply applies the given apply configuration to the status subresource
+	// using server-side apply.  A field manager must be set with FieldOwner.
+	// obj must be a pointer so that it can be updated with the merged object
+	// returned by the Server.
+	Apply(ctx context.Context, obj ApplyConfiguration, opts ...ApplyOption) error
+}
+
+// SubResourceClientConstructor knows how to create a client which can read
+// and write arbitrary subresources of Kubernetes objects.
+type SubResourceClientConstructor interface {
+	// SubResource returns a client for the named subresource, e.g. "scale",
+	// "eviction", "token" or "approval".
+	SubResource(subResource string) SubResourceClient
+}
+
+// SubResourceClient knows how to read and write a subresource of Kubernetes
+// objects.
+type SubResourceClient interface {
+	SubResourceReader
+	SubResourceWriter
+}
+
+// SubResourceReader knows how to read subresources of Kubernetes objects.
+type SubResourceReader interface {
+	// Get retrieves the subresource of obj into subResource, e.g. an
+	// autoscalingv1.Scale for the scale subresource.  subResource must be a
+	// struct pointer so that it can be updated with the response returned by
+	// the Server.
+	Get(ctx context.Context, obj Object, subResource Object) error
+}
+
+// SubResourceWriter knows how to create and update subresources of Kubernetes
+// objects.
+type SubResourceWriter interface {
+	// Create saves subResource as a subresource of obj in the Kubernetes
+	// cluster, e.g. a policyv1beta1.Eviction for the eviction subresource of a
+	// Pod.  subResource must be a struct pointer so that it can be updated
+	// with the response returned by the Server.
+	Create(ctx context.Context, obj Object, subResource Object, opts ...SubResourceCreateOption) error
+
+	// Update updates the subresource of the given obj.  The object itself is
+	// sent unless a different body is given with WithSubResourceBody.  The
+	// body must be a struct pointer so that it can be updated with the
+	// content returned by the Server.
+	Update(ctx context.Context, obj Object, opts ...SubResourceUpdateOption) error
+
+	// Patch patches the subresource of the given obj.  The patch is
+	// calculated from the object itself unless a different body is given
+	// with WithSubResourceBody.  The body must be a struct pointer so that it
+	// can be updated with the content returned by the Server.
+	Patch(ctx context.Context, obj Object, patch Patch, opts ...SubResourcePatchOption) error
 }
 
 // Client knows how to perform CRUD operations on Kubernetes objects.
@@ -101,6 +178,7 @@ type Client interface {
 	Reader
 	Writer
 	StatusClient
+	SubResourceClientConstructor
 
 	// Scheme returns the scheme this client is using.
 	Scheme() *runtime.Scheme
@@ -18,12 +18,14 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/metadata"
 )
 
@@ -113,8 +115,44 @@ func (mc *metadataClient) Patch(ctx context.Context, obj Object, patch Patch, op
 	return nil
 }
 
+// Apply implements client.Client
+func (mc *metadataClient) Apply(ctx context.Context, obj ApplyConfiguration, opts ...ApplyOption) error {
+	return mc.apply(ctx, obj, opts)
+}
+
+func (mc *metadataClient) apply(ctx context.Context, obj ApplyConfiguration, opts []ApplyOption, subResources ...string) error {
+	metadata, ok := obj.(*metav1.PartialObjectMetadata)
+	if !ok {
+		return fmt.Errorf("metadata client did not understand object: %T", obj)
+	}
+
+	gvk := metadata.GroupVersionKind()
+	resInt, err := mc.getResourceInterface(gvk, metadata.Namespace)
+	if err != nil {
+		return err
+	}
+
+	applyOpts := &ApplyOptions{}
+	applyOpts.ApplyOptions(opts)
+	if applyOpts.FieldManager == "" {
+		return ErrFieldManagerRequired
+	}
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	res, err := resInt.Patch(ctx, metadata.Name, types.ApplyPatchType, data, *applyOpts.AsPatchOptions(), subResources...)
+	if err != nil {
+		return err
+	}
+	*metadata = *res
+	metadata.SetGroupVersionKind(gvk) // restore the GVK, which isn't set on metadata
+	return nil
+}
+
 // Get implements client.Client
-func (mc *metadataClient) Get(ctx context.Context, key ObjectKey, obj Object) error {
+func (mc *metadataClient) Get(ctx context.Context, key ObjectKey, obj Object, opts ...GetOption) error {
 	metadata, ok := obj.(*metav1.PartialObjectMetadata)
 	if !ok {
 		return fmt.Errorf("metadata client did not understand object: %T", obj)
@@ -127,7 +165,10 @@ func (mc *metadataClient) Get(ctx context.Context, key ObjectKey, obj Object) er
 		return err
 	}
 
-	res, err := resInt.Get(ctx, key.Name, metav1.GetOptions{})
+	getOpts := GetOptions{}
+	getOpts.ApplyOptions(opts)
+
+	res, err := resInt.Get(ctx, key.Name, *getOpts.AsGetOptions())
 	if err != nil {
 		return err
 	}
@@ -191,3 +232,7 @@ func (mc *metadataClient) PatchStatus(ctx context.Context, obj Object, patch Pat
 	metadata.SetGroupVersionKind(gvk) // restore the GVK, which isn't set on metadata
 	return nil
 }
+
+func (mc *metadataClient) ApplyStatus(ctx context.Context, obj ApplyConfiguration, opts ...ApplyOption) error {
+	return mc.apply(ctx, obj, opts, "status")
+}
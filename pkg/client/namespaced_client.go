@@ -18,6 +18,7 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -179,8 +180,55 @@ func (n *namespacedClient) Patch(ctx context.Context, obj Object, patch Patch, o
 	return n.client.Patch(ctx, obj, patch, opts...)
 }
 
+// Apply implements client.Client
+func (n *namespacedClient) Apply(ctx context.Context, obj ApplyConfiguration, opts ...ApplyOption) error {
+	return applyInNamespace(ctx, n.client, n.namespace, obj, n.client.Apply, opts)
+}
+
+// applyInNamespace enforces the given namespace on an apply configuration
+// before handing it to apply.  Apply configurations that are not Objects are
+// converted to unstructured objects first, and the server's response is copied
+// back into them afterwards.
+func applyInNamespace(ctx context.Context, c Client, namespace string, obj ApplyConfiguration,
+	apply func(context.Context, ApplyConfiguration, ...ApplyOption) error, opts []ApplyOption) error {
+	target, isObject := obj.(Object)
+	if !isObject {
+		u, err := ApplyConfigurationToUnstructured(obj, c.Scheme())
+		if err != nil {
+			return err
+		}
+		target = u
+	}
+
+	isNamespaceScoped, err := isNamespaced(c, target)
+	if err != nil {
+		return fmt.Errorf("error finding the scope of the object: %v", err)
+	}
+
+	objectNamespace := target.GetNamespace()
+	if objectNamespace != namespace && objectNamespace != "" {
+		return fmt.Errorf("Namespace %s of the object %s does not match the namespace %s on the client", objectNamespace, target.GetName(), namespace)
+	}
+
+	if isNamespaceScoped && objectNamespace == "" {
+		target.SetNamespace(namespace)
+	}
+	if err := apply(ctx, target, opts...); err != nil {
+		return err
+	}
+	if isObject {
+		return nil
+	}
+
+	data, err := json.Marshal(target)
+	if err != nil {
+		return err
+	}
+	return decodeApplyResult(data, obj)
+}
+
 // Get implements client.Client
-func (n *namespacedClient) Get(ctx context.Context, key ObjectKey, obj Object) error {
+func (n *namespacedClient) Get(ctx context.Context, key ObjectKey, obj Object, opts ...GetOption) error {
 	isNamespaceScoped, err := isNamespaced(n.client, obj)
 	if err != nil {
 		return fmt.Errorf("error finding the scope of the object: %v", err)
@@ -191,7 +239,7 @@ func (n *namespacedClient) Get(ctx context.Context, key ObjectKey, obj Object) e
 		}
 		key.Namespace = n.namespace
 	}
-	return n.client.Get(ctx, key, obj)
+	return n.client.Get(ctx, key, obj, opts...)
 }
 
 // List implements client.Client
@@ -251,3 +299,73 @@ func (nsw *namespacedClientStatusWriter) Patch(ctx context.Context, obj Object,
 	}
 	return nsw.StatusClient.Patch(ctx, obj, patch, opts...)
 }
+
+// Apply implements client.StatusWriter
+func (nsw *namespacedClientStatusWriter) Apply(ctx context.Context, obj ApplyConfiguration, opts ...ApplyOption) error {
+	return applyInNamespace(ctx, nsw.namespacedclient, nsw.namespace, obj, nsw.StatusClient.Apply, opts)
+}
+
+// SubResource implements client.SubResourceClientConstructor
+func (n *namespacedClient) SubResource(subResource string) SubResourceClient {
+	return &namespacedClientSubResourceClient{client: n.client.SubResource(subResource), namespace: n.namespace, namespacedclient: n}
+}
+
+// ensure namespacedClientSubResourceClient implements client.SubResourceClient
+var _ SubResourceClient = &namespacedClientSubResourceClient{}
+
+type namespacedClientSubResourceClient struct {
+	client           SubResourceClient
+	namespace        string
+	namespacedclient Client
+}
+
+// enforceNamespace checks that obj is in the namespace of the client, or sets
+// it if obj is namespace scoped and has no namespace yet.
+func (nsc *namespacedClientSubResourceClient) enforceNamespace(obj Object) error {
+	isNamespaceScoped, err := isNamespaced(nsc.namespacedclient, obj)
+	if err != nil {
+		return fmt.Errorf("error finding the scope of the object: %v", err)
+	}
+
+	objectNamespace := obj.GetNamespace()
+	if objectNamespace != nsc.namespace && objectNamespace != "" {
+		return fmt.Errorf("Namespace %s of the object %s does not match the namespace %s on the client", objectNamespace, obj.GetName(), nsc.namespace)
+	}
+
+	if isNamespaceScoped && objectNamespace == "" {
+		obj.SetNamespace(nsc.namespace)
+	}
+	return nil
+}
+
+// Get implements client.SubResourceClient
+func (nsc *namespacedClientSubResourceClient) Get(ctx context.Context, obj Object, subResource Object) error {
+	if err := nsc.enforceNamespace(obj); err != nil {
+		return err
+	}
+	return nsc.client.Get(ctx, obj, subResource)
+}
+
+// Create implements client.SubResourceClient
+func (nsc *namespacedClientSubResourceClient) Create(ctx context.Context, obj Object, subResource Object, opts ...SubResourceCreateOption) error {
+	if err := nsc.enforceNamespace(obj); err != nil {
+		return err
+	}
+	return nsc.client.Create(ctx, obj, subResource, opts...)
+}
+
+// Update implements client.SubResourceClient
+func (nsc *namespacedClientSubResourceClient) Update(ctx context.Context, obj Object, opts ...SubResourceUpdateOption) error {
+	if err := nsc.enforceNamespace(obj); err != nil {
+		return err
+	}
+	return nsc.client.Update(ctx, obj, opts...)
+}
+
+// Patch implements client.SubResourceClient
+func (nsc *namespacedClientSubResourceClient) Patch(ctx context.Context, obj Object, patch Patch, opts ...SubResourcePatchOption) error {
+	if err := nsc.enforceNamespace(obj); err != nil {
+		return err
+	}
+	return nsc.client.Patch(ctx, obj, patch, opts...)
+}
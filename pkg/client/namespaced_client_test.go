@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	appsv1ac "k8s.io/client-go/applyconfigurations/apps/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -409,6 +410,39 @@ var _ = Describe("NamespacedClient", func() {
 		})
 	})
 
+	Describe("Apply", func() {
+		var err error
+		BeforeEach(func() {
+			dep, err = clientset.AppsV1().Deployments(ns).Create(ctx, dep, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			deleteDeployment(ctx, dep, ns)
+		})
+
+		It("should successfully apply the configuration when namespace is not provided", func() {
+			By("Applying an apply configuration without a namespace")
+			applyConfig := appsv1ac.Deployment(dep.Name, "").
+				WithAnnotations(map[string]string{"foo": "bar"})
+			err = getClient().Apply(ctx, applyConfig, client.FieldOwner("namespaced-test"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*applyConfig.Namespace).To(Equal(ns))
+
+			By("validating applied Deployment has new annotations")
+			actual, err := clientset.AppsV1().Deployments(ns).Get(ctx, dep.Name, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Annotations["foo"]).To(Equal("bar"))
+		})
+
+		It("should not apply the configuration when its namespace is different", func() {
+			applyConfig := appsv1ac.Deployment(dep.Name, "non-default").
+				WithAnnotations(map[string]string{"foo": "bar"})
+			err = getClient().Apply(ctx, applyConfig, client.FieldOwner("namespaced-test"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Describe("Delete and DeleteAllOf", func() {
 		var err error
 		BeforeEach(func() {
@@ -557,6 +591,9 @@ var _ = Describe("NamespacedClient", func() {
 			err = getClient().Status().Update(ctx, nil)
 			Expect(err).To(HaveOccurred())
 
+			err = getClient().Apply(ctx, nil, client.FieldOwner("namespaced-test"))
+			Expect(err).To(HaveOccurred())
+
 		})
 
 	})
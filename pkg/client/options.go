@@ -37,6 +37,12 @@ type DeleteOption interface {
 	ApplyToDelete(*DeleteOptions)
 }
 
+// GetOption is some configuration that modifies options for a get request.
+type GetOption interface {
+	// ApplyToGet applies this configuration to the given get options.
+	ApplyToGet(*GetOptions)
+}
+
 // ListOption is some configuration that modifies options for a list request.
 type ListOption interface {
 	// ApplyToList applies this configuration to the given list options.
@@ -55,6 +61,33 @@ type PatchOption interface {
 	ApplyToPatch(*PatchOptions)
 }
 
+// ApplyOption is some configuration that modifies options for an apply request.
+type ApplyOption interface {
+	// ApplyToApply applies this configuration to the given apply options.
+	ApplyToApply(*ApplyOptions)
+}
+
+// SubResourceCreateOption is some configuration that modifies options for a create request
+// on a subresource.
+type SubResourceCreateOption interface {
+	// ApplyToSubResourceCreate applies this configuration to the given create options.
+	ApplyToSubResourceCreate(*SubResourceCreateOptions)
+}
+
+// SubResourceUpdateOption is some configuration that modifies options for an update request
+// on a subresource.
+type SubResourceUpdateOption interface {
+	// ApplyToSubResourceUpdate applies this configuration to the given update options.
+	ApplyToSubResourceUpdate(*SubResourceUpdateOptions)
+}
+
+// SubResourcePatchOption is some configuration that modifies options for a patch request
+// on a subresource.
+type SubResourcePatchOption interface {
+	// ApplyToSubResourcePatch applies this configuration to the given patch options.
+	ApplyToSubResourcePatch(*SubResourcePatchOptions)
+}
+
 // DeleteAllOfOption is some configuration that modifies options for a delete request.
 type DeleteAllOfOption interface {
 	// ApplyToDeleteAllOf applies this configuration to the given deletecollection options.
@@ -94,6 +127,26 @@ func (dryRunAll) ApplyToDeleteAllOf(opts *DeleteAllOfOptions) {
 	opts.DryRun = []string{metav1.DryRunAll}
 }
 
+// ApplyToApply applies this configuration to the given apply options.
+func (dryRunAll) ApplyToApply(opts *ApplyOptions) {
+	opts.DryRun = []string{metav1.DryRunAll}
+}
+
+// ApplyToSubResourceCreate applies this configuration to the given subresource create options.
+func (dryRunAll) ApplyToSubResourceCreate(opts *SubResourceCreateOptions) {
+	opts.DryRun = []string{metav1.DryRunAll}
+}
+
+// ApplyToSubResourceUpdate applies this configuration to the given subresource update options.
+func (dryRunAll) ApplyToSubResourceUpdate(opts *SubResourceUpdateOptions) {
+	opts.DryRun = []string{metav1.DryRunAll}
+}
+
+// ApplyToSubResourcePatch applies this configuration to the given subresource patch options.
+func (dryRunAll) ApplyToSubResourcePatch(opts *SubResourcePatchOptions) {
+	opts.DryRun = []string{metav1.DryRunAll}
+}
+
 // FieldOwner set the field manager name for the given server-side apply patch.
 type FieldOwner string
 
@@ -112,6 +165,26 @@ func (f FieldOwner) ApplyToUpdate(opts *UpdateOptions) {
 	opts.FieldManager = string(f)
 }
 
+// ApplyToApply applies this configuration to the given apply options.
+func (f FieldOwner) ApplyToApply(opts *ApplyOptions) {
+	opts.FieldManager = string(f)
+}
+
+// ApplyToSubResourceCreate applies this configuration to the given subresource create options.
+func (f FieldOwner) ApplyToSubResourceCreate(opts *SubResourceCreateOptions) {
+	opts.FieldManager = string(f)
+}
+
+// ApplyToSubResourceUpdate applies this configuration to the given subresource update options.
+func (f FieldOwner) ApplyToSubResourceUpdate(opts *SubResourceUpdateOptions) {
+	opts.FieldManager = string(f)
+}
+
+// ApplyToSubResourcePatch applies this configuration to the given subresource patch options.
+func (f FieldOwner) ApplyToSubResourcePatch(opts *SubResourcePatchOptions) {
+	opts.FieldManager = string(f)
+}
+
 // }}}
 
 // {{{ Create Options
@@ -311,6 +384,53 @@ func (p PropagationPolicy) ApplyToDeleteAllOf(opts *DeleteAllOfOptions) {
 
 // }}}
 
+// {{{ Get Options
+
+// GetOptions contains options for get operation.
+type GetOptions struct {
+	// UnsafeDisableDeepCopy indicates not to deep copy the object during get.
+	// Be very careful with this, when enabled you must DeepCopy the object before mutating it,
+	// otherwise you will mutate the object in the cache.
+	// +optional
+	UnsafeDisableDeepCopy *bool
+
+	// Raw represents raw GetOptions, as passed to the API server.  Note
+	// that these may not be respected by all implementations of interface.
+	Raw *metav1.GetOptions
+}
+
+var _ GetOption = &GetOptions{}
+
+// ApplyToGet implements GetOption for GetOptions
+func (o *GetOptions) ApplyToGet(lo *GetOptions) {
+	if o.Raw != nil {
+		lo.Raw = o.Raw
+	}
+	if o.UnsafeDisableDeepCopy != nil {
+		lo.UnsafeDisableDeepCopy = o.UnsafeDisableDeepCopy
+	}
+}
+
+// AsGetOptions returns these options as a flattened metav1.GetOptions.
+// This may mutate the Raw field.
+func (o *GetOptions) AsGetOptions() *metav1.GetOptions {
+	if o == nil || o.Raw == nil {
+		return &metav1.GetOptions{}
+	}
+	return o.Raw
+}
+
+// ApplyOptions applies the given get options on these options,
+// and then returns itself (for convenient chaining).
+func (o *GetOptions) ApplyOptions(opts []GetOption) *GetOptions {
+	for _, opt := range opts {
+		opt.ApplyToGet(o)
+	}
+	return o
+}
+
+// }}}
+
 // {{{ List Options
 
 // ListOptions contains options for limiting or filtering results.
@@ -321,15 +441,22 @@ type ListOptions struct {
 	// LabelSelector filters results by label.  Use SetLabelSelector to
 	// set from raw string form.
 	LabelSelector labels.Selector
-	// FieldSelector filters results by a particular field.  In order
-	// to use this with cache-based implementations, restrict usage to
-	// a single field-value pair that's been added to the indexers.
+	// FieldSelector filters results by a particular field.  Cache-based
+	// implementations resolve requirements on fields that have been added
+	// to the indexers with the indices, and evaluate the others by scanning
+	// the cached objects, which is slower on large caches.
 	FieldSelector fields.Selector
 
 	// Namespace represents the namespace to list for, or empty for
 	// non-namespaced objects, or to list across all namespaces.
 	Namespace string
 
+	// UnsafeDisableDeepCopy indicates not to deep copy objects during list objects.
+	// Be very careful with this, when enabled you must DeepCopy any object before mutating it,
+	// otherwise you will mutate the object in the cache.
+	// +optional
+	UnsafeDisableDeepCopy *bool
+
 	// Limit specifies the maximum number of results to return from the server. The server may
 	// not support this field on all resource types, but if it does and more results remain it
 	// will set the continue field on the returned list object. This field is not supported if watch
@@ -339,6 +466,14 @@ type ListOptions struct {
 	// from the server by specifying limit. The server may reject requests for continuation tokens
 	// it does not recognize and will return a 410 error if the token can no longer be used because
 	// it has expired. This field is not supported if watch is true in the Raw ListOptions.
+	//
+	// Cache-based implementations support Limit and Continue too, ordering results by
+	// namespace and name.  Their continue tokens can't be used with the API server, nor
+	// the other way around.  Unlike the API server, which serves every page from a
+	// snapshot of the first one, they serve each page from the current contents of the
+	// cache: objects added or deleted between pages may or may not be returned, the
+	// others are returned exactly once, and tokens never expire.  Every page reports
+	// the resource version of the first one.
 	Continue string
 
 	// Raw represents raw ListOptions, as passed to the API server.  Note
@@ -369,6 +504,9 @@ func (o *ListOptions) ApplyToList(lo *ListOptions) {
 	if o.Continue != "" {
 		lo.Continue = o.Continue
 	}
+	if o.UnsafeDisableDeepCopy != nil {
+		lo.UnsafeDisableDeepCopy = o.UnsafeDisableDeepCopy
+	}
 }
 
 // AsListOptions returns these options as a flattened metav1.ListOptions.
@@ -521,6 +659,32 @@ func (c Continue) ApplyToList(opts *ListOptions) {
 	opts.Continue = string(c)
 }
 
+// UnsafeDisableDeepCopyOption indicates not to deep copy objects during get or
+// list.  Implementations backed by a cache (e.g. the informer cache) will then
+// return the objects they hold in their store.
+//
+// Be very careful with this: objects returned this way are shared with the
+// cache and every other caller, so they must never be mutated.  DeepCopy any
+// object before modifying it, otherwise you will corrupt the cache.  Since
+// they can't be modified, their GroupVersionKind is not filled in either.
+type UnsafeDisableDeepCopyOption bool
+
+// ApplyToGet applies this configuration to the given an Get options.
+func (d UnsafeDisableDeepCopyOption) ApplyToGet(opts *GetOptions) {
+	disable := bool(d)
+	opts.UnsafeDisableDeepCopy = &disable
+}
+
+// ApplyToList applies this configuration to the given an List options.
+func (d UnsafeDisableDeepCopyOption) ApplyToList(opts *ListOptions) {
+	disable := bool(d)
+	opts.UnsafeDisableDeepCopy = &disable
+}
+
+// UnsafeDisableDeepCopy indicates not to deep copy objects during get or list.
+// See UnsafeDisableDeepCopyOption.
+const UnsafeDisableDeepCopy = UnsafeDisableDeepCopyOption(true)
+
 // }}}
 
 // {{{ Update Options
@@ -664,6 +828,85 @@ func (forceOwnership) ApplyToPatch(opts *PatchOptions) {
 	opts.Force = &definitelyTrue
 }
 
+func (forceOwnership) ApplyToApply(opts *ApplyOptions) {
+	definitelyTrue := true
+	opts.Force = &definitelyTrue
+}
+
+func (forceOwnership) ApplyToSubResourcePatch(opts *SubResourcePatchOptions) {
+	definitelyTrue := true
+	opts.Force = &definitelyTrue
+}
+
+// }}}
+
+// {{{ Apply Options
+
+// ApplyOptions contains options for server-side apply requests.
+type ApplyOptions struct {
+	// When present, indicates that modifications should not be
+	// persisted. An invalid or unrecognized dryRun directive will
+	// result in an error response and no further processing of the
+	// request. Valid values are:
+	// - All: all dry run stages will be processed
+	DryRun []string
+
+	// Force is going to "force" Apply requests. It means user will
+	// re-acquire conflicting fields owned by other people.
+	// +optional
+	Force *bool
+
+	// FieldManager is the name of the user or component submitting
+	// this request.  It is required for apply requests.
+	FieldManager string
+
+	// Raw represents raw PatchOptions, as passed to the API server.
+	Raw *metav1.PatchOptions
+}
+
+// ApplyOptions applies the given apply options on these options,
+// and then returns itself (for convenient chaining).
+func (o *ApplyOptions) ApplyOptions(opts []ApplyOption) *ApplyOptions {
+	for _, opt := range opts {
+		opt.ApplyToApply(o)
+	}
+	return o
+}
+
+// AsPatchOptions returns these options as a metav1.PatchOptions.
+// This may mutate the Raw field.
+func (o *ApplyOptions) AsPatchOptions() *metav1.PatchOptions {
+	if o == nil {
+		return &metav1.PatchOptions{}
+	}
+	if o.Raw == nil {
+		o.Raw = &metav1.PatchOptions{}
+	}
+
+	o.Raw.DryRun = o.DryRun
+	o.Raw.Force = o.Force
+	o.Raw.FieldManager = o.FieldManager
+	return o.Raw
+}
+
+var _ ApplyOption = &ApplyOptions{}
+
+// ApplyToApply implements ApplyOption
+func (o *ApplyOptions) ApplyToApply(ao *ApplyOptions) {
+	if o.DryRun != nil {
+		ao.DryRun = o.DryRun
+	}
+	if o.Force != nil {
+		ao.Force = o.Force
+	}
+	if o.FieldManager != "" {
+		ao.FieldManager = o.FieldManager
+	}
+	if o.Raw != nil {
+		ao.Raw = o.Raw
+	}
+}
+
 // }}}
 
 // {{{ DeleteAllOf Options
@@ -695,3 +938,117 @@ func (o *DeleteAllOfOptions) ApplyToDeleteAllOf(do *DeleteAllOfOptions) {
 }
 
 // }}}
+
+// {{{ SubResource Options
+
+// SubResourceCreateOptions contains options for create requests on a
+// subresource.  It's just the regular create options.
+type SubResourceCreateOptions struct {
+	CreateOptions
+}
+
+// ApplyOptions applies the given subresource create options on these options,
+// and then returns itself (for convenient chaining).
+func (o *SubResourceCreateOptions) ApplyOptions(opts []SubResourceCreateOption) *SubResourceCreateOptions {
+	for _, opt := range opts {
+		opt.ApplyToSubResourceCreate(o)
+	}
+	return o
+}
+
+var _ SubResourceCreateOption = &SubResourceCreateOptions{}
+
+// ApplyToSubResourceCreate implements SubResourceCreateOption
+func (o *SubResourceCreateOptions) ApplyToSubResourceCreate(co *SubResourceCreateOptions) {
+	o.CreateOptions.ApplyToCreate(&co.CreateOptions)
+}
+
+// SubResourceUpdateOptions contains options for update requests on a
+// subresource.  It's the regular update options plus an optional body.
+type SubResourceUpdateOptions struct {
+	UpdateOptions
+
+	// SubResourceBody is the object that is sent to the subresource and that
+	// receives the response, e.g. an autoscalingv1.Scale for the scale
+	// subresource.  If unset, the object itself is used.
+	SubResourceBody Object
+}
+
+// ApplyOptions applies the given subresource update options on these options,
+// and then returns itself (for convenient chaining).
+func (o *SubResourceUpdateOptions) ApplyOptions(opts []SubResourceUpdateOption) *SubResourceUpdateOptions {
+	for _, opt := range opts {
+		opt.ApplyToSubResourceUpdate(o)
+	}
+	return o
+}
+
+var _ SubResourceUpdateOption = &SubResourceUpdateOptions{}
+
+// ApplyToSubResourceUpdate implements SubResourceUpdateOption
+func (o *SubResourceUpdateOptions) ApplyToSubResourceUpdate(uo *SubResourceUpdateOptions) {
+	o.UpdateOptions.ApplyToUpdate(&uo.UpdateOptions)
+	if o.SubResourceBody != nil {
+		uo.SubResourceBody = o.SubResourceBody
+	}
+}
+
+// SubResourcePatchOptions contains options for patch requests on a
+// subresource.  It's the regular patch options plus an optional body.
+type SubResourcePatchOptions struct {
+	PatchOptions
+
+	// SubResourceBody is the object that the patch is calculated from and
+	// that receives the response, e.g. an autoscalingv1.Scale for the scale
+	// subresource.  If unset, the object itself is used.
+	SubResourceBody Object
+}
+
+// ApplyOptions applies the given subresource patch options on these options,
+// and then returns itself (for convenient chaining).
+func (o *SubResourcePatchOptions) ApplyOptions(opts []SubResourcePatchOption) *SubResourcePatchOptions {
+	for _, opt := range opts {
+		opt.ApplyToSubResourcePatch(o)
+	}
+	return o
+}
+
+var _ SubResourcePatchOption = &SubResourcePatchOptions{}
+
+// ApplyToSubResourcePatch implements SubResourcePatchOption
+func (o *SubResourcePatchOptions) ApplyToSubResourcePatch(po *SubResourcePatchOptions) {
+	o.PatchOptions.ApplyToPatch(&po.PatchOptions)
+	if o.SubResourceBody != nil {
+		po.SubResourceBody = o.SubResourceBody
+	}
+}
+
+// WithSubResourceBody sets the object that is sent to and received from a
+// subresource on update and patch requests, e.g. an autoscalingv1.Scale for
+// the scale subresource.
+func WithSubResourceBody(body Object) SubResourceUpdateAndPatchOption {
+	return &withSubResourceBody{body: body}
+}
+
+// SubResourceUpdateAndPatchOption is an option that can be used with both
+// subresource update and patch requests.
+type SubResourceUpdateAndPatchOption interface {
+	SubResourceUpdateOption
+	SubResourcePatchOption
+}
+
+type withSubResourceBody struct {
+	body Object
+}
+
+// ApplyToSubResourceUpdate applies this configuration to the given subresource update options.
+func (w *withSubResourceBody) ApplyToSubResourceUpdate(opts *SubResourceUpdateOptions) {
+	opts.SubResourceBody = w.body
+}
+
+// ApplyToSubResourcePatch applies this configuration to the given subresource patch options.
+func (w *withSubResourceBody) ApplyToSubResourcePatch(opts *SubResourcePatchOptions) {
+	opts.SubResourceBody = w.body
+}
+
+// }}}
@@ -66,6 +66,19 @@ var _ = Describe("ListOptions", func() {
 		o.ApplyToList(newListOpts)
 		Expect(newListOpts).To(Equal(o))
 	})
+	It("Should set UnsafeDisableDeepCopy", func() {
+		definitelyTrue := true
+		o := &client.ListOptions{UnsafeDisableDeepCopy: &definitelyTrue}
+		newListOpts := &client.ListOptions{}
+		o.ApplyToList(newListOpts)
+		Expect(newListOpts).To(Equal(o))
+	})
+	It("Should be set by UnsafeDisableDeepCopy", func() {
+		newListOpts := &client.ListOptions{}
+		client.UnsafeDisableDeepCopy.ApplyToList(newListOpts)
+		Expect(newListOpts.UnsafeDisableDeepCopy).NotTo(BeNil())
+		Expect(*newListOpts.UnsafeDisableDeepCopy).To(BeTrue())
+	})
 	It("Should not set anything", func() {
 		o := &client.ListOptions{}
 		newListOpts := &client.ListOptions{}
@@ -74,6 +87,34 @@ var _ = Describe("ListOptions", func() {
 	})
 })
 
+var _ = Describe("GetOptions", func() {
+	It("Should set Raw", func() {
+		o := &client.GetOptions{Raw: &metav1.GetOptions{ResourceVersion: "RV0"}}
+		newGetOpts := &client.GetOptions{}
+		o.ApplyToGet(newGetOpts)
+		Expect(newGetOpts).To(Equal(o))
+	})
+	It("Should set UnsafeDisableDeepCopy", func() {
+		definitelyTrue := true
+		o := &client.GetOptions{UnsafeDisableDeepCopy: &definitelyTrue}
+		newGetOpts := &client.GetOptions{}
+		o.ApplyToGet(newGetOpts)
+		Expect(newGetOpts).To(Equal(o))
+	})
+	It("Should be set by UnsafeDisableDeepCopy", func() {
+		newGetOpts := &client.GetOptions{}
+		client.UnsafeDisableDeepCopy.ApplyToGet(newGetOpts)
+		Expect(newGetOpts.UnsafeDisableDeepCopy).NotTo(BeNil())
+		Expect(*newGetOpts.UnsafeDisableDeepCopy).To(BeTrue())
+	})
+	It("Should not set anything", func() {
+		o := &client.GetOptions{}
+		newGetOpts := &client.GetOptions{}
+		o.ApplyToGet(newGetOpts)
+		Expect(newGetOpts).To(Equal(o))
+	})
+})
+
 var _ = Describe("CreateOptions", func() {
 	It("Should set DryRun", func() {
 		o := &client.CreateOptions{DryRun: []string{"Hello", "Theodore"}}
@@ -201,6 +242,76 @@ var _ = Describe("PatchOptions", func() {
 	})
 })
 
+var _ = Describe("ApplyOptions", func() {
+	It("Should set DryRun", func() {
+		o := &client.ApplyOptions{DryRun: []string{"Bye", "Boris"}}
+		newApplyOpts := &client.ApplyOptions{}
+		o.ApplyToApply(newApplyOpts)
+		Expect(newApplyOpts).To(Equal(o))
+	})
+	It("Should set Force", func() {
+		o := &client.ApplyOptions{Force: utilpointer.BoolPtr(true)}
+		newApplyOpts := &client.ApplyOptions{}
+		o.ApplyToApply(newApplyOpts)
+		Expect(newApplyOpts).To(Equal(o))
+	})
+	It("Should set FieldManager", func() {
+		o := &client.ApplyOptions{FieldManager: "Hello Julian"}
+		newApplyOpts := &client.ApplyOptions{}
+		o.ApplyToApply(newApplyOpts)
+		Expect(newApplyOpts).To(Equal(o))
+	})
+	It("Should set Raw", func() {
+		o := &client.ApplyOptions{Raw: &metav1.PatchOptions{}}
+		newApplyOpts := &client.ApplyOptions{}
+		o.ApplyToApply(newApplyOpts)
+		Expect(newApplyOpts).To(Equal(o))
+	})
+	It("Should not set anything", func() {
+		o := &client.ApplyOptions{}
+		newApplyOpts := &client.ApplyOptions{}
+		o.ApplyToApply(newApplyOpts)
+		Expect(newApplyOpts).To(Equal(o))
+	})
+	It("Should be set by FieldOwner, ForceOwnership and DryRunAll", func() {
+		newApplyOpts := (&client.ApplyOptions{}).ApplyOptions([]client.ApplyOption{
+			client.FieldOwner("Hello Julian"), client.ForceOwnership, client.DryRunAll,
+		})
+		Expect(newApplyOpts.AsPatchOptions()).To(Equal(&metav1.PatchOptions{
+			DryRun:       []string{metav1.DryRunAll},
+			Force:        utilpointer.BoolPtr(true),
+			FieldManager: "Hello Julian",
+		}))
+	})
+})
+
+var _ = Describe("SubResourceOptions", func() {
+	It("Should set the update options and body", func() {
+		body := &metav1.PartialObjectMetadata{}
+		o := (&client.SubResourceUpdateOptions{}).ApplyOptions([]client.SubResourceUpdateOption{
+			client.DryRunAll, client.FieldOwner("Hello Boris"), client.WithSubResourceBody(body),
+		})
+		Expect(o.DryRun).To(Equal([]string{metav1.DryRunAll}))
+		Expect(o.FieldManager).To(Equal("Hello Boris"))
+		Expect(o.SubResourceBody).To(BeIdenticalTo(body))
+	})
+	It("Should set the patch options and body", func() {
+		body := &metav1.PartialObjectMetadata{}
+		o := (&client.SubResourcePatchOptions{}).ApplyOptions([]client.SubResourcePatchOption{
+			client.ForceOwnership, client.FieldOwner("Hello Julian"), client.WithSubResourceBody(body),
+		})
+		Expect(o.Force).To(Equal(utilpointer.BoolPtr(true)))
+		Expect(o.FieldManager).To(Equal("Hello Julian"))
+		Expect(o.SubResourceBody).To(BeIdenticalTo(body))
+	})
+	It("Should set the create options", func() {
+		o := &client.SubResourceCreateOptions{CreateOptions: client.CreateOptions{DryRun: []string{"Bye", "Pippa"}}}
+		newCreateOpts := &client.SubResourceCreateOptions{}
+		o.ApplyToSubResourceCreate(newCreateOpts)
+		Expect(newCreateOpts).To(Equal(o))
+	})
+})
+
 var _ = Describe("DeleteAllOfOptions", func() {
 	It("Should set ListOptions", func() {
 		o := &client.DeleteAllOfOptions{ListOptions: client.ListOptions{Raw: &metav1.ListOptions{}}}
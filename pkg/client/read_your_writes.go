@@ -0,0 +1,447 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	// defaultReadYourWritesTimeout is how long a cache read waits for the
+	// cache to observe a write if no timeout was configured.
+	defaultReadYourWritesTimeout = 5 * time.Second
+
+	// readYourWritesPollInterval is how often the cache is checked while
+	// waiting for it to observe a write.
+	readYourWritesPollInterval = 10 * time.Millisecond
+)
+
+// writeKey identifies an object written through the delegating client.
+type writeKey struct {
+	gvk schema.GroupVersionKind
+	key ObjectKey
+}
+
+// writeRecord is what the delegating client remembers about the last write
+// of an object until the cache has caught up with it.
+type writeRecord struct {
+	resourceVersion string
+	uid             types.UID
+	deleted         bool
+	// written is when the write was recorded.
+	written time.Time
+}
+
+// observedBy returns true if obj, as read from the cache, reflects the
+// write.  A deletion counts as observed once the cached object is being
+// deleted or has been replaced by a different object.  Objects deleted by
+// key carry no UID, so for those only a deletion timestamp (or the object
+// missing from the cache) tells that the cache has seen the deletion.
+func (r writeRecord) observedBy(obj Object) bool {
+	if r.deleted {
+		if r.uid == "" {
+			return obj.GetDeletionTimestamp() != nil
+		}
+		return obj.GetDeletionTimestamp() != nil || obj.GetUID() != r.uid
+	}
+	return resourceVersionAtLeast(obj.GetResourceVersion(), r.resourceVersion)
+}
+
+// resourceVersionAtLeast returns true if current is the same as or newer than
+// target.  Resource versions are opaque, but are integers for every storage
+// backend in use, so they are compared numerically when possible.
+func resourceVersionAtLeast(current, target string) bool {
+	if current == target {
+		return true
+	}
+	c, err := strconv.ParseUint(current, 10, 64)
+	if err != nil {
+		return false
+	}
+	t, err := strconv.ParseUint(target, 10, 64)
+	if err != nil {
+		return false
+	}
+	return c >= t
+}
+
+// writeTracker records the writes done through the delegating client that
+// the cache may not have observed yet.  Writes are forgotten once the cache
+// observed them, or once ttl has passed: a read doesn't wait longer than that
+// for the cache anyway, and if the cache hasn't caught up by then it is
+// unlikely to ever see the write.
+type writeTracker struct {
+	scheme *runtime.Scheme
+	ttl    time.Duration
+
+	mu     sync.Mutex
+	writes map[writeKey]writeRecord
+}
+
+func newWriteTracker(scheme *runtime.Scheme, ttl time.Duration) *writeTracker {
+	return &writeTracker{
+		scheme: scheme,
+		ttl:    ttl,
+		writes: map[writeKey]writeRecord{},
+	}
+}
+
+func (t *writeTracker) keyFor(obj runtime.Object, meta metav1.Object) (writeKey, error) {
+	gvk, err := apiutil.GVKForObject(obj, t.scheme)
+	if err != nil {
+		return writeKey{}, err
+	}
+	return writeKey{gvk: gvk, key: ObjectKey{Namespace: meta.GetNamespace(), Name: meta.GetName()}}, nil
+}
+
+// recordWrite remembers the resource version the server returned for obj.
+// Objects without a resource version, e.g. from dry-run requests, are
+// ignored.
+func (t *writeTracker) recordWrite(obj Object) error {
+	if obj.GetResourceVersion() == "" {
+		return nil
+	}
+	k, err := t.keyFor(obj, obj)
+	if err != nil {
+		return err
+	}
+	t.record(k, writeRecord{resourceVersion: obj.GetResourceVersion(), uid: obj.GetUID()})
+	return nil
+}
+
+// recordApply remembers the resource version the server returned for an
+// apply request.
+func (t *writeTracker) recordApply(obj ApplyConfiguration) error {
+	u, err := ApplyConfigurationToUnstructured(obj, t.scheme)
+	if err != nil {
+		return err
+	}
+	return t.recordWrite(u)
+}
+
+// recordDelete remembers that obj has been deleted.
+func (t *writeTracker) recordDelete(obj Object) error {
+	k, err := t.keyFor(obj, obj)
+	if err != nil {
+		return err
+	}
+	t.record(k, writeRecord{uid: obj.GetUID(), deleted: true})
+	return nil
+}
+
+// record remembers rec as the last write of the given key, and drops the
+// writes that expired.
+func (t *writeTracker) record(k writeKey, rec writeRecord) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	rec.written = time.Now()
+	for key, old := range t.writes {
+		if t.expired(old, rec.written) {
+			delete(t.writes, key)
+		}
+	}
+	t.writes[k] = rec
+}
+
+// expired returns true if rec was written more than ttl before now.
+func (t *writeTracker) expired(rec writeRecord, now time.Time) bool {
+	return now.Sub(rec.written) > t.ttl
+}
+
+// get returns the pending write for the given key, if any.
+func (t *writeTracker) get(k writeKey) (writeRecord, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	rec, ok := t.writes[k]
+	if ok && t.expired(rec, time.Now()) {
+		delete(t.writes, k)
+		return writeRecord{}, false
+	}
+	return rec, ok
+}
+
+// pendingFor returns the pending writes of the given kind in the given
+// namespace, or in all namespaces if namespace is empty.
+func (t *writeTracker) pendingFor(gvk schema.GroupVersionKind, namespace string) map[writeKey]writeRecord {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	pending := map[writeKey]writeRecord{}
+	for k, rec := range t.writes {
+		if t.expired(rec, now) {
+			delete(t.writes, k)
+			continue
+		}
+		if k.gvk == gvk && (namespace == "" || k.key.Namespace == namespace) {
+			pending[k] = rec
+		}
+	}
+	return pending
+}
+
+// forget drops the pending write for the given key, unless it has been
+// replaced by a newer write in the meantime.
+func (t *writeTracker) forget(k writeKey, rec writeRecord) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.writes[k] == rec {
+		delete(t.writes, k)
+	}
+}
+
+// readYourWritesWriter is a Writer that records every successful write in a
+// writeTracker.
+type readYourWritesWriter struct {
+	Writer
+	tracker *writeTracker
+}
+
+// Create implements client.Writer.
+func (w *readYourWritesWriter) Create(ctx context.Context, obj Object, opts ...CreateOption) error {
+	if err := w.Writer.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	return w.tracker.recordWrite(obj)
+}
+
+// Update implements client.Writer.
+func (w *readYourWritesWriter) Update(ctx context.Context, obj Object, opts ...UpdateOption) error {
+	if err := w.Writer.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	return w.tracker.recordWrite(obj)
+}
+
+// Patch implements client.Writer.
+func (w *readYourWritesWriter) Patch(ctx context.Context, obj Object, patch Patch, opts ...PatchOption) error {
+	if err := w.Writer.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	return w.tracker.recordWrite(obj)
+}
+
+// Apply implements client.Writer.
+func (w *readYourWritesWriter) Apply(ctx context.Context, obj ApplyConfiguration, opts ...ApplyOption) error {
+	if err := w.Writer.Apply(ctx, obj, opts...); err != nil {
+		return err
+	}
+	return w.tracker.recordApply(obj)
+}
+
+// Delete implements client.Writer.
+func (w *readYourWritesWriter) Delete(ctx context.Context, obj Object, opts ...DeleteOption) error {
+	if err := w.Writer.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+	deleteOpts := DeleteOptions{}
+	deleteOpts.ApplyOptions(opts)
+	if len(deleteOpts.DryRun) > 0 {
+		return nil
+	}
+	return w.tracker.recordDelete(obj)
+}
+
+// readYourWritesStatusClient is a StatusClient whose status writes are
+// recorded in a writeTracker.
+type readYourWritesStatusClient struct {
+	StatusClient
+	tracker *writeTracker
+}
+
+// Status implements client.StatusClient.
+func (c *readYourWritesStatusClient) Status() StatusWriter {
+	return &readYourWritesStatusWriter{StatusWriter: c.StatusClient.Status(), tracker: c.tracker}
+}
+
+// readYourWritesStatusWriter is a StatusWriter that records every successful
+// write in a writeTracker.
+type readYourWritesStatusWriter struct {
+	StatusWriter
+	tracker *writeTracker
+}
+
+// Update implements client.StatusWriter.
+func (w *readYourWritesStatusWriter) Update(ctx context.Context, obj Object, opts ...UpdateOption) error {
+	if err := w.StatusWriter.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	return w.tracker.recordWrite(obj)
+}
+
+// Patch implements client.StatusWriter.
+func (w *readYourWritesStatusWriter) Patch(ctx context.Context, obj Object, patch Patch, opts ...PatchOption) error {
+	if err := w.StatusWriter.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	return w.tracker.recordWrite(obj)
+}
+
+// Apply implements client.StatusWriter.
+func (w *readYourWritesStatusWriter) Apply(ctx context.Context, obj ApplyConfiguration, opts ...ApplyOption) error {
+	if err := w.StatusWriter.Apply(ctx, obj, opts...); err != nil {
+		return err
+	}
+	return w.tracker.recordApply(obj)
+}
+
+// getAfterWrites reads obj from the cache once the cache has observed the
+// last write of it done through this client, falling back to the API server
+// if that doesn't happen within the configured timeout.
+func (d *delegatingReader) getAfterWrites(ctx context.Context, key ObjectKey, obj Object, opts ...GetOption) error {
+	k, err := d.writes.keyFor(obj, &metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name})
+	if err != nil {
+		return err
+	}
+	rec, pending := d.writes.get(k)
+	if !pending {
+		return d.CacheReader.Get(ctx, key, obj, opts...)
+	}
+
+	var cacheErr error
+	observed, err := d.waitForCache(ctx, func() (bool, error) {
+		cacheErr = d.CacheReader.Get(ctx, key, obj, opts...)
+		switch {
+		case apierrors.IsNotFound(cacheErr):
+			return rec.deleted, nil
+		case cacheErr != nil:
+			return false, cacheErr
+		}
+		return rec.observedBy(obj), nil
+	})
+	if err != nil {
+		return err
+	}
+	// Either way the next read doesn't need to wait anymore: the cache has
+	// caught up, or it is unlikely to ever see the write (e.g. because it
+	// is filtered out by a selector) and waiting again would be pointless.
+	d.writes.forget(k, rec)
+	if observed {
+		return cacheErr
+	}
+	return d.ClientReader.Get(ctx, key, obj, opts...)
+}
+
+// listAfterWrites lists from the cache once the cache has observed all
+// writes done through this client to objects of the listed kind, falling
+// back to the API server if that doesn't happen within the configured
+// timeout.
+func (d *delegatingReader) listAfterWrites(ctx context.Context, list ObjectList, opts ...ListOption) error {
+	gvk, err := apiutil.GVKForObject(list, d.scheme)
+	if err != nil {
+		return err
+	}
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	listOpts := ListOptions{}
+	listOpts.ApplyOptions(opts)
+	pending := d.writes.pendingFor(gvk, listOpts.Namespace)
+	if len(pending) == 0 {
+		return d.CacheReader.List(ctx, list, opts...)
+	}
+
+	observed, err := d.waitForCache(ctx, func() (bool, error) {
+		for k, rec := range pending {
+			obj, err := d.newObjectForList(list, k.gvk)
+			if err != nil {
+				return false, err
+			}
+			err = d.CacheReader.Get(ctx, k.key, obj, UnsafeDisableDeepCopy)
+			switch {
+			case apierrors.IsNotFound(err):
+				if !rec.deleted {
+					continue
+				}
+			case err != nil:
+				return false, err
+			case !rec.observedBy(obj):
+				continue
+			}
+			d.writes.forget(k, rec)
+			delete(pending, k)
+		}
+		return len(pending) == 0, nil
+	})
+	if err != nil {
+		return err
+	}
+	if observed {
+		return d.CacheReader.List(ctx, list, opts...)
+	}
+	for k, rec := range pending {
+		d.writes.forget(k, rec)
+	}
+	return d.ClientReader.List(ctx, list, opts...)
+}
+
+// newObjectForList returns an empty object of the given kind, in the same
+// representation as the items of list.
+func (d *delegatingReader) newObjectForList(list ObjectList, gvk schema.GroupVersionKind) (Object, error) {
+	switch list.(type) {
+	case *unstructured.UnstructuredList:
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		return u, nil
+	case *metav1.PartialObjectMetadataList:
+		m := &metav1.PartialObjectMetadata{}
+		m.SetGroupVersionKind(gvk)
+		return m, nil
+	}
+	ro, err := d.scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	obj, isObj := ro.(Object)
+	if !isObj {
+		return nil, fmt.Errorf("%T does not implement client.Object", ro)
+	}
+	return obj, nil
+}
+
+// waitForCache calls observed until it returns true or an error, the
+// read-your-writes timeout expires or ctx is done.  It returns false without
+// an error if the timeout expired.
+func (d *delegatingReader) waitForCache(ctx context.Context, observed func() (bool, error)) (bool, error) {
+	timeout := time.NewTimer(d.readYourWritesTimeout)
+	defer timeout.Stop()
+	ticker := time.NewTicker(readYourWritesPollInterval)
+	defer ticker.Stop()
+	for {
+		if ok, err := observed(); ok || err != nil {
+			return ok, err
+		}
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-timeout.C:
+			return false, nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestWriteTrackerExpiresWrites(t *testing.T) {
+	ttl := 50 * time.Millisecond
+	tracker := newWriteTracker(scheme.Scheme, ttl)
+	write := func(name string) {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, ResourceVersion: "1"}}
+		if err := tracker.recordWrite(cm); err != nil {
+			t.Fatalf("failed to record write: %v", err)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		write(fmt.Sprintf("cm-%d", i))
+	}
+	gvk := corev1.SchemeGroupVersion.WithKind("ConfigMap")
+	if pending := tracker.pendingFor(gvk, ""); len(pending) != 100 {
+		t.Fatalf("expected 100 pending writes, got %d", len(pending))
+	}
+
+	time.Sleep(2 * ttl)
+	write("last")
+	if len(tracker.writes) != 1 {
+		t.Fatalf("expected the expired writes to be dropped, got %d writes", len(tracker.writes))
+	}
+
+	time.Sleep(2 * ttl)
+	if pending := tracker.pendingFor(gvk, "default"); len(pending) != 0 {
+		t.Fatalf("expected no pending writes, got %d", len(pending))
+	}
+	if len(tracker.writes) != 0 {
+		t.Fatalf("expected the tracker to be drained, got %d writes", len(tracker.writes))
+	}
+}
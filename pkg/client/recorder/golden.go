@@ -0,0 +1,63 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recorder
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// envUpdateGolden is the environment variable that, when set to true, makes
+// CompareGolden write the golden files instead of comparing them.
+const envUpdateGolden = "UPDATE_GOLDEN"
+
+// CompareGolden compares the calls recorded so far, as YAML, to the content
+// of the golden file at path, and returns an error describing the
+// differences if they don't match.  When the tests are run with the
+// UPDATE_GOLDEN environment variable set to true, the golden file is written
+// instead.
+func (c *Client) CompareGolden(path string) error {
+	actual, err := c.YAML()
+	if err != nil {
+		return err
+	}
+
+	if strings.ToLower(os.Getenv(envUpdateGolden)) == "true" {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		return ioutil.WriteFile(path, actual, 0644)
+	}
+
+	expected, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("golden file %s does not exist, run the tests with %s=true to create it", path, envUpdateGolden)
+	}
+	if err != nil {
+		return err
+	}
+	if diff := cmp.Diff(string(expected), string(actual)); diff != "" {
+		return fmt.Errorf("recorded calls differ from golden file %s, run the tests with %s=true to update it (-expected +actual):\n%s", path, envUpdateGolden, diff)
+	}
+	return nil
+}
@@ -0,0 +1,364 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package recorder provides a client.Client that records the calls made
+// through it, e.g. by a reconciler under test, in a stable YAML format that
+// can be compared against golden files.  It is usually wrapped around a fake
+// client:
+//
+//	c := recorder.NewClient(fake.NewClientBuilder().WithObjects(initObjs...).Build())
+//	... run the reconciler with c ...
+//	Expect(c.CompareGolden("testdata/reconcile.yaml")).To(Succeed())
+//
+// Golden files are written instead of compared when the tests are run with
+// the UPDATE_GOLDEN environment variable set to true:
+//
+//	UPDATE_GOLDEN=true go test ./...
+package recorder
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Call is a call made through the recording client.
+type Call struct {
+	// Verb is the verb of the call: get, list, create, update, patch,
+	// apply, delete or deleteallof.
+	Verb string `json:"verb"`
+	// SubResource is the subresource the call was made on, if any, e.g.
+	// "status".
+	SubResource string `json:"subResource,omitempty"`
+
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name,omitempty"`
+	// GenerateName is the prefix of the name generated by the API server for
+	// create calls of objects without a name.  Generated names are random,
+	// so they are not recorded.
+	GenerateName string `json:"generateName,omitempty"`
+
+	// Options are the options of the call, as sent to the API server.
+	// They are omitted if they are all unset.
+	Options interface{} `json:"options,omitempty"`
+
+	// PatchType and Patch are the type and body of patch and apply calls.
+	PatchType string      `json:"patchType,omitempty"`
+	Patch     interface{} `json:"patch,omitempty"`
+
+	// Error is the reason of the error returned by the call, like NotFound
+	// or Conflict, or its message for errors that aren't API errors.
+	Error string `json:"error,omitempty"`
+}
+
+// Client is a client.Client that records the calls made through it.
+type Client struct {
+	client client.Client
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ client.Client = &Client{}
+
+// NewClient returns a new client that records the calls made through it
+// before passing them to the given client.
+func NewClient(c client.Client) *Client {
+	return &Client{client: c}
+}
+
+// Calls returns the calls recorded so far.
+func (c *Client) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Call(nil), c.calls...)
+}
+
+// Reset forgets the calls recorded so far.
+func (c *Client) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = nil
+}
+
+// YAML returns the calls recorded so far as YAML.
+func (c *Client) YAML() ([]byte, error) {
+	calls := c.Calls()
+	if calls == nil {
+		calls = []Call{}
+	}
+	return yaml.Marshal(calls)
+}
+
+// record records a call on obj.  The kind is taken from obj, unless it is
+// nil, and the suffix of list kinds is removed.
+func (c *Client) record(call Call, obj runtime.Object, err error) {
+	if obj != nil {
+		if gvk, gvkErr := apiutil.GVKForObject(obj, c.client.Scheme()); gvkErr == nil {
+			call.APIVersion = gvk.GroupVersion().String()
+			call.Kind = gvk.Kind
+			if meta.IsListType(obj) {
+				call.Kind = strings.TrimSuffix(call.Kind, "List")
+			}
+		}
+	}
+	if err != nil {
+		call.Error = errorReason(err)
+	}
+	if call.Options != nil && reflect.ValueOf(call.Options).Elem().IsZero() {
+		call.Options = nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, call)
+}
+
+func errorReason(err error) string {
+	if reason := apierrors.ReasonForError(err); reason != "" {
+		return string(reason)
+	}
+	return err.Error()
+}
+
+// patchCall returns a call recording the given patch, computed from obj.
+func patchCall(verb, subResource string, obj client.Object, patch client.Patch) Call {
+	call := Call{Verb: verb, SubResource: subResource, Namespace: obj.GetNamespace(), Name: obj.GetName(), PatchType: string(patch.Type())}
+	data, err := patch.Data(obj)
+	if err != nil {
+		call.Patch = "error: " + err.Error()
+		return call
+	}
+	call.Patch = decodeBody(data)
+	return call
+}
+
+// applyCall returns a call recording the given apply configuration.
+func (c *Client) applyCall(subResource string, obj client.ApplyConfiguration) Call {
+	call := Call{Verb: "apply", SubResource: subResource, PatchType: "application/apply-patch+yaml"}
+	u, err := client.ApplyConfigurationToUnstructured(obj, c.client.Scheme())
+	if err != nil {
+		call.Patch = "error: " + err.Error()
+		return call
+	}
+	call.APIVersion = u.GetAPIVersion()
+	call.Kind = u.GetKind()
+	call.Namespace = u.GetNamespace()
+	call.Name = u.GetName()
+	call.Patch = u.Object
+	return call
+}
+
+// decodeBody decodes a JSON body, so that it is recorded as YAML.  Other
+// bodies are recorded as strings.
+func decodeBody(data []byte) interface{} {
+	var body interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return string(data)
+	}
+	return body
+}
+
+// Get implements client.Client.
+func (c *Client) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	err := c.client.Get(ctx, key, obj, opts...)
+	options := (&client.GetOptions{}).ApplyOptions(opts).AsGetOptions()
+	c.record(Call{Verb: "get", Namespace: key.Namespace, Name: key.Name, Options: options}, obj, err)
+	return err
+}
+
+// List implements client.Client.
+func (c *Client) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	err := c.client.List(ctx, list, opts...)
+	listOpts := (&client.ListOptions{}).ApplyOptions(opts)
+	options := listOpts.AsListOptions()
+	c.record(Call{Verb: "list", Namespace: listOpts.Namespace, Options: options}, list, err)
+	return err
+}
+
+// Create implements client.Client.
+func (c *Client) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	// The name is recorded as it was before the call, which fills in the
+	// names generated by the API server.
+	call := Call{Verb: "create", Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	if call.Name == "" {
+		call.GenerateName = obj.GetGenerateName()
+	}
+	err := c.client.Create(ctx, obj, opts...)
+	call.Options = (&client.CreateOptions{}).ApplyOptions(opts).AsCreateOptions()
+	c.record(call, obj, err)
+	return err
+}
+
+// Delete implements client.Client.
+func (c *Client) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	err := c.client.Delete(ctx, obj, opts...)
+	options := (&client.DeleteOptions{}).ApplyOptions(opts).AsDeleteOptions()
+	c.record(Call{Verb: "delete", Namespace: obj.GetNamespace(), Name: obj.GetName(), Options: options}, obj, err)
+	return err
+}
+
+// deleteAllOfOptions are the options of a deleteallof call.
+type deleteAllOfOptions struct {
+	Delete interface{} `json:"delete,omitempty"`
+	List   interface{} `json:"list,omitempty"`
+}
+
+// DeleteAllOf implements client.Client.
+func (c *Client) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	err := c.client.DeleteAllOf(ctx, obj, opts...)
+	dcOpts := (&client.DeleteAllOfOptions{}).ApplyOptions(opts)
+	options := &deleteAllOfOptions{}
+	if deleteOpts := dcOpts.AsDeleteOptions(); !reflect.ValueOf(*deleteOpts).IsZero() {
+		options.Delete = deleteOpts
+	}
+	if listOpts := dcOpts.AsListOptions(); !reflect.ValueOf(*listOpts).IsZero() {
+		options.List = listOpts
+	}
+	c.record(Call{Verb: "deleteallof", Namespace: dcOpts.Namespace, Options: options}, obj, err)
+	return err
+}
+
+// Update implements client.Client.
+func (c *Client) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	err := c.client.Update(ctx, obj, opts...)
+	options := (&client.UpdateOptions{}).ApplyOptions(opts).AsUpdateOptions()
+	c.record(Call{Verb: "update", Namespace: obj.GetNamespace(), Name: obj.GetName(), Options: options}, obj, err)
+	return err
+}
+
+// Patch implements client.Client.
+func (c *Client) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	// The patch is computed before the call, which updates obj.
+	call := patchCall("patch", "", obj, patch)
+	err := c.client.Patch(ctx, obj, patch, opts...)
+	call.Options = (&client.PatchOptions{}).ApplyOptions(opts).AsPatchOptions()
+	c.record(call, obj, err)
+	return err
+}
+
+// Apply implements client.Client.
+func (c *Client) Apply(ctx context.Context, obj client.ApplyConfiguration, opts ...client.ApplyOption) error {
+	call := c.applyCall("", obj)
+	err := c.client.Apply(ctx, obj, opts...)
+	call.Options = (&client.ApplyOptions{}).ApplyOptions(opts).AsPatchOptions()
+	c.record(call, nil, err)
+	return err
+}
+
+// Status implements client.Client.
+func (c *Client) Status() client.StatusWriter {
+	return &statusWriter{client: c, statusWriter: c.client.Status()}
+}
+
+// SubResource implements client.Client.
+func (c *Client) SubResource(subResource string) client.SubResourceClient {
+	return &subResourceClient{client: c, subResource: subResource, subResourceClient: c.client.SubResource(subResource)}
+}
+
+// Scheme implements client.Client.
+func (c *Client) Scheme() *runtime.Scheme {
+	return c.client.Scheme()
+}
+
+// RESTMapper implements client.Client.
+func (c *Client) RESTMapper() meta.RESTMapper {
+	return c.client.RESTMapper()
+}
+
+type statusWriter struct {
+	client       *Client
+	statusWriter client.StatusWriter
+}
+
+var _ client.StatusWriter = &statusWriter{}
+
+func (s *statusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	err := s.statusWriter.Update(ctx, obj, opts...)
+	options := (&client.UpdateOptions{}).ApplyOptions(opts).AsUpdateOptions()
+	s.client.record(Call{Verb: "update", SubResource: "status", Namespace: obj.GetNamespace(), Name: obj.GetName(), Options: options}, obj, err)
+	return err
+}
+
+func (s *statusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	call := patchCall("patch", "status", obj, patch)
+	err := s.statusWriter.Patch(ctx, obj, patch, opts...)
+	call.Options = (&client.PatchOptions{}).ApplyOptions(opts).AsPatchOptions()
+	s.client.record(call, obj, err)
+	return err
+}
+
+func (s *statusWriter) Apply(ctx context.Context, obj client.ApplyConfiguration, opts ...client.ApplyOption) error {
+	call := s.client.applyCall("status", obj)
+	err := s.statusWriter.Apply(ctx, obj, opts...)
+	call.Options = (&client.ApplyOptions{}).ApplyOptions(opts).AsPatchOptions()
+	s.client.record(call, nil, err)
+	return err
+}
+
+type subResourceClient struct {
+	client            *Client
+	subResource       string
+	subResourceClient client.SubResourceClient
+}
+
+var _ client.SubResourceClient = &subResourceClient{}
+
+func (s *subResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object) error {
+	err := s.subResourceClient.Get(ctx, obj, subResource)
+	s.client.record(Call{Verb: "get", SubResource: s.subResource, Namespace: obj.GetNamespace(), Name: obj.GetName()}, obj, err)
+	return err
+}
+
+func (s *subResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	err := s.subResourceClient.Create(ctx, obj, subResource, opts...)
+	options := (&client.SubResourceCreateOptions{}).ApplyOptions(opts).AsCreateOptions()
+	s.client.record(Call{Verb: "create", SubResource: s.subResource, Namespace: obj.GetNamespace(), Name: obj.GetName(), Options: options}, obj, err)
+	return err
+}
+
+func (s *subResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	err := s.subResourceClient.Update(ctx, obj, opts...)
+	options := (&client.SubResourceUpdateOptions{}).ApplyOptions(opts).AsUpdateOptions()
+	s.client.record(Call{Verb: "update", SubResource: s.subResource, Namespace: obj.GetNamespace(), Name: obj.GetName(), Options: options}, obj, err)
+	return err
+}
+
+func (s *subResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	patchOpts := (&client.SubResourcePatchOptions{}).ApplyOptions(opts)
+	body := obj
+	if patchOpts.SubResourceBody != nil {
+		body = patchOpts.SubResourceBody
+	}
+	call := patchCall("patch", s.subResource, body, patch)
+	call.Namespace, call.Name = obj.GetNamespace(), obj.GetName()
+	err := s.subResourceClient.Patch(ctx, obj, patch, opts...)
+	call.Options = patchOpts.AsPatchOptions()
+	s.client.record(call, obj, err)
+	return err
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recorder
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// labelReconciler labels the configmaps it reconciles, and deletes their
+// "-old" counterpart.
+var labelReconciler = func(c client.Client) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		obj := &corev1.ConfigMap{}
+		if err := c.Get(ctx, req.NamespacedName, obj); err != nil {
+			return reconcile.Result{}, client.IgnoreNotFound(err)
+		}
+		patch := client.MergeFrom(obj.DeepCopy())
+		obj.Labels = map[string]string{"reconciled": "true"}
+		if err := c.Patch(ctx, obj, patch, client.FieldOwner("test")); err != nil {
+			return reconcile.Result{}, err
+		}
+		old := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: req.Name + "-old", Namespace: req.Namespace}}
+		return reconcile.Result{}, client.IgnoreNotFound(c.Delete(ctx, old))
+	})
+}
+
+var _ = Describe("Recording client", func() {
+	var (
+		ctx context.Context
+		c   *Client
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		c = NewClient(fake.NewClientBuilder().WithObjects(
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"}},
+		).Build())
+	})
+
+	It("should record the calls of a reconciler", func() {
+		_, err := labelReconciler(c).Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKey{Name: "cm", Namespace: "ns"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.CompareGolden("testdata/reconcile.yaml")).To(Succeed())
+	})
+
+	It("should record the verb, kind, key and options of calls", func() {
+		Expect(c.List(ctx, &corev1.ConfigMapList{}, client.InNamespace("ns"), client.MatchingLabels{"app": "test"})).To(Succeed())
+		Expect(c.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"}}, client.FieldOwner("test"))).NotTo(Succeed())
+		Expect(c.Status().Update(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns"}})).NotTo(Succeed())
+
+		calls := c.Calls()
+		Expect(calls).To(HaveLen(3))
+		Expect(calls[0]).To(Equal(Call{
+			Verb:       "list",
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Namespace:  "ns",
+			Options:    &metav1.ListOptions{LabelSelector: "app=test"},
+		}))
+		Expect(calls[1]).To(Equal(Call{
+			Verb:       "create",
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Namespace:  "ns",
+			Name:       "cm",
+			Options:    &metav1.CreateOptions{FieldManager: "test"},
+			Error:      "AlreadyExists",
+		}))
+		Expect(calls[2]).To(Equal(Call{
+			Verb:        "update",
+			SubResource: "status",
+			APIVersion:  "v1",
+			Kind:        "Pod",
+			Namespace:   "ns",
+			Name:        "pod",
+			Error:       "NotFound",
+		}))
+
+		c.Reset()
+		Expect(c.Calls()).To(BeEmpty())
+		Expect(c.YAML()).To(Equal([]byte("[]\n")))
+	})
+
+	It("should record the generated name prefix of created objects", func() {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{GenerateName: "cm-", Namespace: "ns"}}
+		Expect(c.Create(ctx, cm)).To(Succeed())
+		Expect(cm.Name).NotTo(BeEmpty())
+
+		Expect(c.Calls()).To(Equal([]Call{{
+			Verb:         "create",
+			APIVersion:   "v1",
+			Kind:         "ConfigMap",
+			Namespace:    "ns",
+			GenerateName: "cm-",
+		}}))
+	})
+
+	It("should report the differences with the golden file", func() {
+		dir, err := ioutil.TempDir("", "recorder")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "golden.yaml")
+
+		By("Failing if the golden file doesn't exist")
+		Expect(c.Get(ctx, client.ObjectKey{Name: "cm", Namespace: "ns"}, &corev1.ConfigMap{})).To(Succeed())
+		err = c.CompareGolden(path)
+		Expect(err).To(MatchError(ContainSubstring("does not exist")))
+
+		By("Writing the golden file with UPDATE_GOLDEN=true")
+		Expect(os.Setenv(envUpdateGolden, "true")).To(Succeed())
+		err = c.CompareGolden(path)
+		Expect(os.Unsetenv(envUpdateGolden)).To(Succeed())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.CompareGolden(path)).To(Succeed())
+
+		By("Failing when the calls differ")
+		Expect(c.Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"}})).To(Succeed())
+		err = c.CompareGolden(path)
+		Expect(err).To(MatchError(ContainSubstring("verb: delete")))
+	})
+})
@@ -19,6 +19,7 @@ package client
 import (
 	"context"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -34,6 +35,21 @@ type NewDelegatingClientInput struct {
 	Client            Client
 	UncachedObjects   []Object
 	CacheUnstructured bool
+
+	// ReadYourWrites makes the client remember the resourceVersion returned
+	// by each create, update, patch, apply and delete done through it, as well
+	// as by status writes.  Cache reads of an object written this way block
+	// until the cache has observed that version or a newer one, and fall back
+	// to reading from the API server if that doesn't happen within
+	// ReadYourWritesTimeout.  Lists wait for all such writes to objects of the
+	// listed kind.  Writes to other subresources and DeleteAllOf are not
+	// tracked.
+	ReadYourWrites bool
+
+	// ReadYourWritesTimeout is how long a cache read waits for the cache to
+	// observe a write when ReadYourWrites is set.  Writes older than that
+	// are not waited for anymore.  Defaults to 5 seconds.
+	ReadYourWritesTimeout time.Duration
 }
 
 // NewDelegatingClient creates a new delegating client.
@@ -51,18 +67,34 @@ func NewDelegatingClient(in NewDelegatingClientInput) (Client, error) {
 		uncachedGVKs[gvk] = struct{}{}
 	}
 
+	reader := &delegatingReader{
+		CacheReader:       in.CacheReader,
+		ClientReader:      in.Client,
+		scheme:            in.Client.Scheme(),
+		uncachedGVKs:      uncachedGVKs,
+		cacheUnstructured: in.CacheUnstructured,
+	}
+	var (
+		writer       Writer       = in.Client
+		statusClient StatusClient = in.Client
+	)
+	if in.ReadYourWrites {
+		reader.readYourWritesTimeout = in.ReadYourWritesTimeout
+		if reader.readYourWritesTimeout <= 0 {
+			reader.readYourWritesTimeout = defaultReadYourWritesTimeout
+		}
+		reader.writes = newWriteTracker(in.Client.Scheme(), reader.readYourWritesTimeout)
+		writer = &readYourWritesWriter{Writer: in.Client, tracker: reader.writes}
+		statusClient = &readYourWritesStatusClient{StatusClient: in.Client, tracker: reader.writes}
+	}
+
 	return &delegatingClient{
-		scheme: in.Client.Scheme(),
-		mapper: in.Client.RESTMapper(),
-		Reader: &delegatingReader{
-			CacheReader:       in.CacheReader,
-			ClientReader:      in.Client,
-			scheme:            in.Client.Scheme(),
-			uncachedGVKs:      uncachedGVKs,
-			cacheUnstructured: in.CacheUnstructured,
-		},
-		Writer:       in.Client,
-		StatusClient: in.Client,
+		scheme:                       in.Client.Scheme(),
+		mapper:                       in.Client.RESTMapper(),
+		Reader:                       reader,
+		Writer:                       writer,
+		StatusClient:                 statusClient,
+		SubResourceClientConstructor: in.Client,
 	}, nil
 }
 
@@ -70,6 +102,7 @@ type delegatingClient struct {
 	Reader
 	Writer
 	StatusClient
+	SubResourceClientConstructor
 
 	scheme *runtime.Scheme
 	mapper meta.RESTMapper
@@ -97,6 +130,11 @@ type delegatingReader struct {
 	uncachedGVKs      map[schema.GroupVersionKind]struct{}
 	scheme            *runtime.Scheme
 	cacheUnstructured bool
+
+	// writes holds the writes the cache may not have observed yet, if
+	// read-your-writes is enabled.
+	writes                *writeTracker
+	readYourWritesTimeout time.Duration
 }
 
 func (d *delegatingReader) shouldBypassCache(obj runtime.Object) (bool, error) {
@@ -121,13 +159,16 @@ func (d *delegatingReader) shouldBypassCache(obj runtime.Object) (bool, error) {
 }
 
 // Get retrieves an obj for a given object key from the Kubernetes Cluster.
-func (d *delegatingReader) Get(ctx context.Context, key ObjectKey, obj Object) error {
+func (d *delegatingReader) Get(ctx context.Context, key ObjectKey, obj Object, opts ...GetOption) error {
 	if isUncached, err := d.shouldBypassCache(obj); err != nil {
 		return err
 	} else if isUncached {
-		return d.ClientReader.Get(ctx, key, obj)
+		return d.ClientReader.Get(ctx, key, obj, opts...)
 	}
-	return d.CacheReader.Get(ctx, key, obj)
+	if d.writes != nil {
+		return d.getAfterWrites(ctx, key, obj, opts...)
+	}
+	return d.CacheReader.Get(ctx, key, obj, opts...)
 }
 
 // List retrieves list of objects for a given namespace and list options.
@@ -137,5 +178,8 @@ func (d *delegatingReader) List(ctx context.Context, list ObjectList, opts ...Li
 	} else if isUncached {
 		return d.ClientReader.List(ctx, list, opts...)
 	}
+	if d.writes != nil {
+		return d.listAfterWrites(ctx, list, opts...)
+	}
 	return d.CacheReader.List(ctx, list, opts...)
 }
@@ -105,6 +105,12 @@ type Options struct {
 	// will only hold objects from the desired namespace.
 	Namespace string
 
+	// SelectorsByObject if specified restricts the manager's cache to only
+	// watch objects of the given types that match the label and field
+	// selectors. Objects filtered out are neither cached nor returned by the
+	// default client's reads. Defaults to watching every object.
+	SelectorsByObject cache.SelectorsByObject
+
 	// NewCache is the function that will create the cache to be used
 	// by the manager. If not set this will use the default new cache function.
 	NewCache cache.NewCacheFunc
@@ -162,7 +168,13 @@ func New(config *rest.Config, opts ...Option) (Cluster, error) {
 	}
 
 	// Create the cache for the cached read client and registering informers
-	cache, err := options.NewCache(config, cache.Options{Scheme: options.Scheme, Mapper: mapper, Resync: options.SyncPeriod, Namespace: options.Namespace})
+	cache, err := options.NewCache(config, cache.Options{
+		Scheme:            options.Scheme,
+		Mapper:            mapper,
+		Resync:            options.SyncPeriod,
+		Namespace:         options.Namespace,
+		SelectorsByObject: options.SelectorsByObject,
+	})
 	if err != nil {
 		return nil, err
 	}
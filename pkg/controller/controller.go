@@ -23,22 +23,28 @@ import (
 
 	"github.com/go-logr/logr"
 	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/internal/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // Options are the arguments for creating a new Controller
-type Options struct {
+type Options = TypedOptions[reconcile.Request]
+
+// TypedOptions are the arguments for creating a new TypedController
+type TypedOptions[request comparable] struct {
 	// MaxConcurrentReconciles is the maximum number of concurrent Reconciles which can be run. Defaults to 1.
 	MaxConcurrentReconciles int
 
 	// Reconciler reconciles an object
-	Reconciler reconcile.Reconciler
+	Reconciler reconcile.TypedReconciler[request]
 
 	// RateLimiter is used to limit how frequently requests may be queued.
 	// Defaults to MaxOfRateLimiter which has both overall and per-item rate limiting.
@@ -52,18 +58,53 @@ type Options struct {
 	// CacheSyncTimeout refers to the time limit set to wait for syncing caches.
 	// Defaults to 2 minutes if not set.
 	CacheSyncTimeout time.Duration
+
+	// UsePriorityQueue configures the controller to use a priorityqueue.PriorityQueue,
+	// which hands out the requests with the highest priority first.  Requests for the
+	// initial list of a source.Kind and for resyncs get priorityqueue.LowPriority, so
+	// that requests for changes made after the controller started aren't delayed by them.
+	// EventHandlers can set the priority of their requests with priorityqueue.AddWithPriority.
+	UsePriorityQueue bool
+
+	// ReconcileTimeout is the deadline put on the context passed to the Reconciler for
+	// each request.  Reconcilers have to honor the context for it to take effect.  No
+	// deadline if zero.
+	ReconcileTimeout time.Duration
+
+	// RecoverPanic indicates whether a panic in the Reconciler is recovered, logged with
+	// its stack trace and counted, instead of crashing the process.  The request is then
+	// requeued with backoff, like when the Reconciler returns an error.
+	RecoverPanic bool
+
+	// PartitionKey, if set, maps each request to a partition, e.g. its namespace with
+	// PartitionByNamespace, so that the requests of a partition can't take all the workers.
+	// At most MaxConcurrentReconcilesPerPartition requests of a partition are reconciled at
+	// once, and the partitions with requests waiting are served in turn.
+	// MaxConcurrentReconciles still limits the total number of concurrent Reconciles.  The
+	// requests of partitions without room are taken out of the queue to wait, so they aren't
+	// counted in its depth metric.
+	PartitionKey func(req request) string
+
+	// MaxConcurrentReconcilesPerPartition is the maximum number of concurrent Reconciles per
+	// partition when PartitionKey is set.  Defaults to 1.
+	MaxConcurrentReconcilesPerPartition int
 }
 
 // Controller implements a Kubernetes API.  A Controller manages a work queue fed reconcile.Requests
 // from source.Sources.  Work is performed through the reconcile.Reconciler for each enqueued item.
 // Work typically is reads and writes Kubernetes objects to make the system state match the state specified
 // in the object Spec.
-type Controller interface {
+type Controller = TypedController[reconcile.Request]
+
+// TypedController is a Controller of requests of type request.  Its watches have to use EventHandlers
+// which enqueue requests of that type, e.g. handler.TypedEnqueueRequestsFromMapFunc.
+type TypedController[request comparable] interface {
 	// Reconciler is called to reconcile an object by Namespace/Name
-	reconcile.Reconciler
+	reconcile.TypedReconciler[request]
 
 	// Watch takes events provided by a Source and uses the EventHandler to
-	// enqueue reconcile.Requests in response to the events.
+	// enqueue reconcile.Requests in response to the events.  Use WatchTyped
+	// for sources of objects of other types than client.Object.
 	//
 	// Watch may be provided one or more Predicates to filter events before
 	// they are given to the EventHandler.  Events will be passed to the
@@ -81,7 +122,13 @@ type Controller interface {
 // New returns a new Controller registered with the Manager.  The Manager will ensure that shared Caches have
 // been synced before the Controller is Started.
 func New(name string, mgr manager.Manager, options Options) (Controller, error) {
-	c, err := NewUnmanaged(name, mgr, options)
+	return NewTyped(name, mgr, options)
+}
+
+// NewTyped returns a new TypedController registered with the Manager.  The Manager will ensure that shared
+// Caches have been synced before the Controller is Started.
+func NewTyped[request comparable](name string, mgr manager.Manager, options TypedOptions[request]) (TypedController[request], error) {
+	c, err := NewTypedUnmanaged(name, mgr, options)
 	if err != nil {
 		return nil, err
 	}
@@ -93,6 +140,12 @@ func New(name string, mgr manager.Manager, options Options) (Controller, error)
 // NewUnmanaged returns a new controller without adding it to the manager. The
 // caller is responsible for starting the returned controller.
 func NewUnmanaged(name string, mgr manager.Manager, options Options) (Controller, error) {
+	return NewTypedUnmanaged(name, mgr, options)
+}
+
+// NewTypedUnmanaged returns a new typed controller without adding it to the manager. The
+// caller is responsible for starting the returned controller.
+func NewTypedUnmanaged[request comparable](name string, mgr manager.Manager, options TypedOptions[request]) (TypedController[request], error) {
 	if options.Reconciler == nil {
 		return nil, fmt.Errorf("must specify Reconciler")
 	}
@@ -123,15 +176,79 @@ func NewUnmanaged(name string, mgr manager.Manager, options Options) (Controller
 	}
 
 	// Create controller with dependencies set
-	return &controller.Controller{
+	return &controller.TypedController[request]{
 		Do: options.Reconciler,
 		MakeQueue: func() workqueue.RateLimitingInterface {
+			if options.UsePriorityQueue {
+				return priorityqueue.New(name, options.RateLimiter)
+			}
 			return workqueue.NewNamedRateLimitingQueue(options.RateLimiter, name)
 		},
-		MaxConcurrentReconciles: options.MaxConcurrentReconciles,
-		CacheSyncTimeout:        options.CacheSyncTimeout,
-		SetFields:               mgr.SetFields,
-		Name:                    name,
-		Log:                     options.Log.WithName("controller").WithName(name),
+		RateLimiter:                         options.RateLimiter,
+		MaxConcurrentReconciles:             options.MaxConcurrentReconciles,
+		CacheSyncTimeout:                    options.CacheSyncTimeout,
+		ReconcileTimeout:                    options.ReconcileTimeout,
+		RecoverPanic:                        options.RecoverPanic,
+		PartitionKey:                        options.PartitionKey,
+		MaxConcurrentReconcilesPerPartition: options.MaxConcurrentReconcilesPerPartition,
+		SetFields:                           mgr.SetFields,
+		Name:                                name,
+		Log:                                 options.Log.WithName("controller").WithName(name),
 	}, nil
 }
+
+// PartitionByNamespace is a PartitionKey which partitions requests by the namespace of their object.
+func PartitionByNamespace(req reconcile.Request) string {
+	return req.Namespace
+}
+
+// WatchTyped is TypedController.Watch for sources of objects of type object, e.g. a source.TypedKind[*v1.Pod],
+// so that the EventHandler and Predicates get objects of that type.
+func WatchTyped[object any, request comparable](c TypedController[request], src source.TypedSource[object],
+	eventhandler handler.TypedEventHandler[object], predicates ...predicate.TypedPredicate[object]) error {
+	return c.Watch(&typedWatch[object]{src: src, handler: eventhandler, predicates: predicates}, handler.Funcs{})
+}
+
+// typedWatch is a Source which starts a TypedSource with the TypedEventHandler and TypedPredicates it was
+// watched with.
+type typedWatch[object any] struct {
+	src        source.TypedSource[object]
+	handler    handler.TypedEventHandler[object]
+	predicates []predicate.TypedPredicate[object]
+}
+
+var _ source.SyncingSource = &typedWatch[client.Object]{}
+
+// Start implements source.Source.  The EventHandler and Predicates given to the Controller are ignored.
+func (w *typedWatch[object]) Start(ctx context.Context, _ handler.EventHandler, queue workqueue.RateLimitingInterface, _ ...predicate.Predicate) error {
+	return w.src.Start(ctx, w.handler, queue, w.predicates...)
+}
+
+// WaitForSync implements source.SyncingSource.
+func (w *typedWatch[object]) WaitForSync(ctx context.Context) error {
+	if syncingSource, ok := w.src.(interface{ WaitForSync(context.Context) error }); ok {
+		return syncingSource.WaitForSync(ctx)
+	}
+	return nil
+}
+
+// InjectFunc implements inject.Injector, to inject the dependencies of the typed source, handler and
+// predicates.
+func (w *typedWatch[object]) InjectFunc(f inject.Func) error {
+	if err := f(w.src); err != nil {
+		return err
+	}
+	if err := f(w.handler); err != nil {
+		return err
+	}
+	for _, p := range w.predicates {
+		if err := f(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *typedWatch[object]) String() string {
+	return fmt.Sprintf("%v", w.src)
+}
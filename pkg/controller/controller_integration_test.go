@@ -28,6 +28,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -172,6 +173,67 @@ var _ = Describe("controller", func() {
 
 			close(done)
 		}, 5)
+
+		It("should reconcile typed requests", func(done Done) {
+			type deploymentRequest struct {
+				Namespace, Name string
+				Image           string
+			}
+			typedReconciled := make(chan deploymentRequest)
+
+			By("Creating the Manager")
+			cm, err := manager.New(cfg, manager.Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Creating the Controller")
+			instance, err := controller.NewTyped("typed-controller", cm, controller.TypedOptions[deploymentRequest]{
+				Reconciler: reconcile.TypedFunc[deploymentRequest](
+					func(_ context.Context, request deploymentRequest) (reconcile.Result, error) {
+						typedReconciled <- request
+						return reconcile.Result{}, nil
+					}),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Watching Deployments with typed handlers and predicates")
+			err = controller.WatchTyped[*appsv1.Deployment](instance, &source.TypedKind[*appsv1.Deployment]{Type: &appsv1.Deployment{}},
+				handler.TypedEnqueueRequestsFromMapFunc(func(d *appsv1.Deployment) []deploymentRequest {
+					return []deploymentRequest{{Namespace: d.Namespace, Name: d.Name, Image: d.Spec.Template.Spec.Containers[0].Image}}
+				}),
+				predicate.NewTypedPredicateFuncs(func(d *appsv1.Deployment) bool {
+					return d.Name == "typed-deployment"
+				}))
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Starting the Manager")
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				defer GinkgoRecover()
+				Expect(cm.Start(ctx)).NotTo(HaveOccurred())
+			}()
+
+			By("Invoking Reconciling for Create")
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "typed-deployment"},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"foo": "bar"},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"foo": "bar"}},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}},
+						},
+					},
+				},
+			}
+			_, err = clientset.AppsV1().Deployments("default").Create(ctx, deployment, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(<-typedReconciled).To(Equal(deploymentRequest{Namespace: "default", Name: "typed-deployment", Image: "nginx"}))
+
+			close(done)
+		}, 5)
 	})
 })
 
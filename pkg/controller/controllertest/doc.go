@@ -14,7 +14,9 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package controllertest contains fake informers for testing controllers
+// Package controllertest contains fake informers for testing controllers,
+// and RunReconciler, which drives a Reconciler to convergence the way a
+// controller does, using a virtual clock.
 // When in doubt, it's almost always better to test against a real API server
 // using envtest.Environment.
 package controllertest
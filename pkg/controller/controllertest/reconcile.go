@@ -0,0 +1,331 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllertest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const defaultMaxSteps = 100
+
+// ReconcileOptions are the optional arguments of RunReconciler.
+type ReconcileOptions struct {
+	// Watch are the list types of the objects whose changes are recorded
+	// in the trace, e.g. &corev1.ConfigMapList{}.  They are listed in all
+	// namespaces with the client before and after each step.
+	Watch []client.ObjectList
+
+	// MaxSteps is the maximum number of calls to Reconcile.  Reaching it
+	// with requests left is an error.  Defaults to 100.
+	MaxSteps int
+
+	// MaxDuration stops the run, without error, once the requests left are
+	// all scheduled after this much virtual time.  This allows running
+	// reconcilers that periodically requeue requests.  No limit if zero.
+	MaxDuration time.Duration
+
+	// RateLimiter computes the delay before requeuing requests that failed
+	// or returned Requeue.  Defaults to the per-item exponential backoff
+	// used by the controllers, from 5ms to 1000s.
+	RateLimiter workqueue.RateLimiter
+}
+
+// ReconcileTrace records the calls made to a Reconciler by RunReconciler.
+type ReconcileTrace struct {
+	// Steps are the calls to Reconcile, in order.
+	Steps []ReconcileStep
+
+	// Converged is true if there was nothing left to reconcile at the end
+	// of the run.
+	Converged bool
+}
+
+// ReconcileStep is a single call to Reconcile.
+type ReconcileStep struct {
+	// Time is the time of the virtual clock of the run when Reconcile was
+	// called, since the start of the run.
+	Time time.Duration
+
+	Request reconcile.Request
+	Result  reconcile.Result
+	Err     error
+
+	// Changes are the changes made to the watched objects during the call.
+	Changes []ObjectChange
+}
+
+// ObjectChange is a change made to an object.
+type ObjectChange struct {
+	GroupVersionKind schema.GroupVersionKind
+	Key              client.ObjectKey
+
+	// Before is the object before the change, nil if it was created.
+	Before client.Object
+	// After is the object after the change, nil if it was deleted.
+	After client.Object
+	// Diff is a human readable diff of the object, ignoring its managed
+	// fields.
+	Diff string
+}
+
+// String returns a human readable description of the trace.
+func (t *ReconcileTrace) String() string {
+	b := &strings.Builder{}
+	for i, step := range t.Steps {
+		fmt.Fprintf(b, "step %d at %v: %v: result %+v", i, step.Time, step.Request, step.Result)
+		if step.Err != nil {
+			fmt.Fprintf(b, ", error %v", step.Err)
+		}
+		b.WriteString("\n")
+		for _, change := range step.Changes {
+			fmt.Fprintf(b, "  %v %v:\n%s", change.GroupVersionKind.Kind, change.Key, change.Diff)
+		}
+	}
+	if !t.Converged {
+		b.WriteString("not converged\n")
+	}
+	return b.String()
+}
+
+// RunReconciler calls r.Reconcile for the given requests, and requeues them
+// the way a controller does, until there is nothing left to reconcile:
+// requests that fail or return Requeue are retried after the delay of the
+// rate limiter, unless they failed with a reconcile.TerminalError, and
+// requests that return RequeueAfter after that delay.  A request that is
+// requeued while it is already waiting is only reconciled once, at the
+// earliest of the two times.
+//
+// Delays don't actually elapse: the run uses a virtual clock, which jumps to
+// the time of the next request whenever nothing is ready.  Events caused by
+// the changes the reconciler makes aren't simulated, so requests for other
+// objects have to be part of the initial requests, or returned by the
+// reconciler through requeues.
+//
+// RunReconciler returns the trace of the run, and an error if the reconciler
+// didn't converge within MaxSteps or if listing the watched objects failed.
+func RunReconciler(ctx context.Context, r reconcile.Reconciler, c client.Client, requests []reconcile.Request, opts ReconcileOptions) (*ReconcileTrace, error) {
+	if opts.MaxSteps <= 0 {
+		opts.MaxSteps = defaultMaxSteps
+	}
+	if opts.RateLimiter == nil {
+		opts.RateLimiter = workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, 1000*time.Second)
+	}
+
+	queue := &virtualQueue{pending: map[reconcile.Request]*queuedRequest{}}
+	for _, req := range requests {
+		queue.addAfter(req, 0)
+	}
+
+	trace := &ReconcileTrace{}
+	before, err := snapshot(ctx, c, opts.Watch)
+	if err != nil {
+		return trace, err
+	}
+	for len(trace.Steps) < opts.MaxSteps {
+		if queue.len() == 0 {
+			trace.Converged = true
+			return trace, nil
+		}
+		if opts.MaxDuration > 0 && queue.next().at > opts.MaxDuration {
+			return trace, nil
+		}
+		req := queue.pop()
+
+		step := ReconcileStep{Time: queue.now, Request: req}
+		step.Result, step.Err = r.Reconcile(ctx, req)
+
+		after, err := snapshot(ctx, c, opts.Watch)
+		if err != nil {
+			return trace, err
+		}
+		step.Changes = changes(before, after)
+		before = after
+		trace.Steps = append(trace.Steps, step)
+
+		// Requeue the request the same way the controller does.
+		switch {
+		case reconcile.IsTerminalError(step.Err):
+			opts.RateLimiter.Forget(req)
+		case step.Err != nil:
+			queue.addAfter(req, opts.RateLimiter.When(req))
+		case step.Result.RequeueAfter > 0:
+			opts.RateLimiter.Forget(req)
+			queue.addAfter(req, step.Result.RequeueAfter)
+		case step.Result.Requeue:
+			queue.addAfter(req, opts.RateLimiter.When(req))
+		default:
+			opts.RateLimiter.Forget(req)
+		}
+	}
+
+	if queue.len() == 0 {
+		trace.Converged = true
+		return trace, nil
+	}
+	return trace, fmt.Errorf("reconciler did not converge within %d steps, %d requests left", opts.MaxSteps, queue.len())
+}
+
+// virtualQueue is a delaying queue of requests driven by a virtual clock.
+type virtualQueue struct {
+	now     time.Duration
+	pending map[reconcile.Request]*queuedRequest
+	// added counts the requests added, to reconcile requests due at the
+	// same time in the order they were added.
+	added int
+}
+
+type queuedRequest struct {
+	req   reconcile.Request
+	at    time.Duration
+	order int
+}
+
+func (q *virtualQueue) len() int {
+	return len(q.pending)
+}
+
+func (q *virtualQueue) addAfter(req reconcile.Request, delay time.Duration) {
+	at := q.now + delay
+	if queued, ok := q.pending[req]; ok {
+		if at < queued.at {
+			queued.at = at
+		}
+		return
+	}
+	q.added++
+	q.pending[req] = &queuedRequest{req: req, at: at, order: q.added}
+}
+
+// next returns the request due first, which must exist.
+func (q *virtualQueue) next() *queuedRequest {
+	var next *queuedRequest
+	for _, queued := range q.pending {
+		if next == nil || queued.at < next.at || (queued.at == next.at && queued.order < next.order) {
+			next = queued
+		}
+	}
+	return next
+}
+
+// pop removes the request due first and advances the clock to its time, if
+// it is in the future.
+func (q *virtualQueue) pop() reconcile.Request {
+	next := q.next()
+	delete(q.pending, next.req)
+	if next.at > q.now {
+		q.now = next.at
+	}
+	return next.req
+}
+
+// objectID identifies an object across kinds.
+type objectID struct {
+	gvk schema.GroupVersionKind
+	key client.ObjectKey
+}
+
+// snapshot returns the objects of the given list types, keyed by ID.
+func snapshot(ctx context.Context, c client.Client, lists []client.ObjectList) (map[objectID]client.Object, error) {
+	objs := map[objectID]client.Object{}
+	for _, list := range lists {
+		list = list.DeepCopyObject().(client.ObjectList)
+		gvk, err := apiutil.GVKForObject(list, c.Scheme())
+		if err != nil {
+			return nil, err
+		}
+		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+		if err := c.List(ctx, list); err != nil {
+			return nil, fmt.Errorf("failed to list %v: %w", gvk, err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				return nil, fmt.Errorf("%T does not implement client.Object", item)
+			}
+			objs[objectID{gvk: gvk, key: client.ObjectKeyFromObject(obj)}] = obj
+		}
+	}
+	return objs, nil
+}
+
+// changes returns the changes between two snapshots, sorted by kind and key.
+func changes(before, after map[objectID]client.Object) []ObjectChange {
+	var ids []objectID
+	for id := range before {
+		ids = append(ids, id)
+	}
+	for id := range after {
+		if _, ok := before[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if ids[i].gvk != ids[j].gvk {
+			return ids[i].gvk.String() < ids[j].gvk.String()
+		}
+		return ids[i].key.String() < ids[j].key.String()
+	})
+
+	var result []ObjectChange
+	for _, id := range ids {
+		diff := cmp.Diff(contentOf(before[id]), contentOf(after[id]))
+		if diff == "" {
+			continue
+		}
+		result = append(result, ObjectChange{
+			GroupVersionKind: id.gvk,
+			Key:              id.key,
+			Before:           before[id],
+			After:            after[id],
+			Diff:             diff,
+		})
+	}
+	return result
+}
+
+// contentOf returns the content of obj without its managed fields, or nil
+// if obj is nil.
+func contentOf(obj client.Object) map[string]interface{} {
+	if obj == nil {
+		return nil
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return map[string]interface{}{"error": err.Error()}
+	}
+	unstructured.RemoveNestedField(content, "metadata", "managedFields")
+	return content
+}
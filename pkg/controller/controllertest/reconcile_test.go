@@ -0,0 +1,165 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllertest_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("RunReconciler", func() {
+	var (
+		ctx context.Context
+		c   client.Client
+		req reconcile.Request
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		c = fake.NewClientBuilder().Build()
+		req = reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "ns", Name: "cm"}}
+	})
+
+	times := func(trace *controllertest.ReconcileTrace) []time.Duration {
+		var result []time.Duration
+		for _, step := range trace.Steps {
+			result = append(result, step.Time)
+		}
+		return result
+	}
+
+	It("should requeue requests and record the changes made to objects", func() {
+		calls := 0
+		r := reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+			calls++
+			switch calls {
+			case 1:
+				cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: req.Namespace, Name: req.Name}}
+				return reconcile.Result{Requeue: true}, c.Create(ctx, cm)
+			case 2:
+				cm := &corev1.ConfigMap{}
+				if err := c.Get(ctx, req.NamespacedName, cm); err != nil {
+					return reconcile.Result{}, err
+				}
+				cm.Data = map[string]string{"key": "value"}
+				return reconcile.Result{RequeueAfter: time.Minute}, c.Update(ctx, cm)
+			default:
+				return reconcile.Result{}, nil
+			}
+		})
+
+		trace, err := controllertest.RunReconciler(ctx, r, c, []reconcile.Request{req}, controllertest.ReconcileOptions{
+			Watch: []client.ObjectList{&corev1.ConfigMapList{}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trace.Converged).To(BeTrue())
+		Expect(times(trace)).To(Equal([]time.Duration{0, 5 * time.Millisecond, time.Minute + 5*time.Millisecond}))
+
+		Expect(trace.Steps[0].Changes).To(HaveLen(1))
+		Expect(trace.Steps[0].Changes[0].Key).To(Equal(req.NamespacedName))
+		Expect(trace.Steps[0].Changes[0].Before).To(BeNil())
+		Expect(trace.Steps[0].Changes[0].After).NotTo(BeNil())
+
+		Expect(trace.Steps[1].Changes).To(HaveLen(1))
+		Expect(trace.Steps[1].Changes[0].Diff).To(ContainSubstring("value"))
+		Expect(trace.Steps[1].Changes[0].After.(*corev1.ConfigMap).Data).To(Equal(map[string]string{"key": "value"}))
+
+		Expect(trace.Steps[2].Changes).To(BeEmpty())
+		Expect(trace.String()).To(ContainSubstring("step 2 at 1m0.005s"))
+	})
+
+	It("should back off exponentially on errors", func() {
+		calls := 0
+		r := reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+			calls++
+			if calls < 4 {
+				return reconcile.Result{}, errors.New("failure")
+			}
+			return reconcile.Result{}, nil
+		})
+
+		trace, err := controllertest.RunReconciler(ctx, r, c, []reconcile.Request{req}, controllertest.ReconcileOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trace.Converged).To(BeTrue())
+		Expect(times(trace)).To(Equal([]time.Duration{0, 5 * time.Millisecond, 15 * time.Millisecond, 35 * time.Millisecond}))
+		Expect(trace.Steps[0].Err).To(MatchError("failure"))
+		Expect(trace.Steps[3].Err).NotTo(HaveOccurred())
+	})
+
+	It("should not requeue requests that fail with a terminal error", func() {
+		r := reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+			return reconcile.Result{}, reconcile.TerminalError(errors.New("invalid"))
+		})
+
+		trace, err := controllertest.RunReconciler(ctx, r, c, []reconcile.Request{req}, controllertest.ReconcileOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trace.Converged).To(BeTrue())
+		Expect(trace.Steps).To(HaveLen(1))
+		Expect(reconcile.IsTerminalError(trace.Steps[0].Err)).To(BeTrue())
+	})
+
+	It("should reconcile requests queued more than once a single time", func() {
+		other := reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "ns", Name: "other"}}
+		var reconciled []reconcile.Request
+		r := reconcile.Func(func(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+			reconciled = append(reconciled, req)
+			return reconcile.Result{}, nil
+		})
+
+		trace, err := controllertest.RunReconciler(ctx, r, c, []reconcile.Request{req, other, req}, controllertest.ReconcileOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trace.Converged).To(BeTrue())
+		Expect(reconciled).To(Equal([]reconcile.Request{req, other}))
+	})
+
+	It("should stop after the maximum duration", func() {
+		r := reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+			return reconcile.Result{RequeueAfter: 10 * time.Minute}, nil
+		})
+
+		trace, err := controllertest.RunReconciler(ctx, r, c, []reconcile.Request{req}, controllertest.ReconcileOptions{
+			MaxDuration: time.Hour,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trace.Converged).To(BeFalse())
+		Expect(trace.Steps).To(HaveLen(7))
+	})
+
+	It("should fail if the reconciler doesn't converge within the maximum steps", func() {
+		r := reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+			return reconcile.Result{Requeue: true}, nil
+		})
+
+		trace, err := controllertest.RunReconciler(ctx, r, c, []reconcile.Request{req}, controllertest.ReconcileOptions{
+			MaxSteps: 5,
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(trace.Converged).To(BeFalse())
+		Expect(trace.Steps).To(HaveLen(5))
+	})
+})
@@ -87,6 +87,16 @@ func (f *FakeInformer) Delete(obj metav1.Object) {
 	}
 }
 
+// Remove fakes the removal of the informer from its cache, notifying the
+// handlers that implement OnInformerRemoved()
+func (f *FakeInformer) Remove() {
+	for _, h := range f.handlers {
+		if removedHandler, ok := h.(interface{ OnInformerRemoved() }); ok {
+			removedHandler.OnInformerRemoved()
+		}
+	}
+}
+
 // AddEventHandlerWithResyncPeriod does nothing.  TODO(community): Implement this.
 func (f *FakeInformer) AddEventHandlerWithResyncPeriod(handler cache.ResourceEventHandler, resyncPeriod time.Duration) {
 
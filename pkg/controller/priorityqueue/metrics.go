@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priorityqueue
+
+import (
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+// This file is adapted from k8s.io/client-go/util/workqueue, whose metrics
+// can't be reused by other queues.
+
+// unfinishedWorkUpdatePeriod is how often the metrics of the work in
+// progress are updated, like for the queues of the workqueue package.
+const unfinishedWorkUpdatePeriod = 500 * time.Millisecond
+
+// queueMetrics reports the same metrics as the queues of the workqueue
+// package.  It isn't safe for concurrent use.
+type queueMetrics struct {
+	// current depth of a workqueue
+	depth workqueue.GaugeMetric
+	// total number of adds handled by a workqueue
+	adds workqueue.CounterMetric
+	// how long an item stays in a workqueue
+	latency workqueue.HistogramMetric
+	// how long processing an item from a workqueue takes
+	workDuration         workqueue.HistogramMetric
+	addTimes             map[interface{}]time.Time
+	processingStartTimes map[interface{}]time.Time
+
+	// how long have current threads been working?
+	unfinishedWorkSeconds   workqueue.SettableGaugeMetric
+	longestRunningProcessor workqueue.SettableGaugeMetric
+
+	// total number of retries handled by a workqueue
+	retries workqueue.CounterMetric
+}
+
+func newQueueMetrics(provider workqueue.MetricsProvider, name string) *queueMetrics {
+	return &queueMetrics{
+		depth:                   provider.NewDepthMetric(name),
+		adds:                    provider.NewAddsMetric(name),
+		latency:                 provider.NewLatencyMetric(name),
+		workDuration:            provider.NewWorkDurationMetric(name),
+		addTimes:                map[interface{}]time.Time{},
+		processingStartTimes:    map[interface{}]time.Time{},
+		unfinishedWorkSeconds:   provider.NewUnfinishedWorkSecondsMetric(name),
+		longestRunningProcessor: provider.NewLongestRunningProcessorSecondsMetric(name),
+		retries:                 provider.NewRetriesMetric(name),
+	}
+}
+
+func (m *queueMetrics) add(item interface{}) {
+	m.adds.Inc()
+	m.depth.Inc()
+	if _, exists := m.addTimes[item]; !exists {
+		m.addTimes[item] = time.Now()
+	}
+}
+
+func (m *queueMetrics) get(item interface{}) {
+	m.depth.Dec()
+	m.processingStartTimes[item] = time.Now()
+	if startTime, exists := m.addTimes[item]; exists {
+		m.latency.Observe(time.Since(startTime).Seconds())
+		delete(m.addTimes, item)
+	}
+}
+
+func (m *queueMetrics) done(item interface{}) {
+	if startTime, exists := m.processingStartTimes[item]; exists {
+		m.workDuration.Observe(time.Since(startTime).Seconds())
+		delete(m.processingStartTimes, item)
+	}
+}
+
+func (m *queueMetrics) updateUnfinishedWork() {
+	var total float64
+	var oldest float64
+	for _, t := range m.processingStartTimes {
+		age := time.Since(t).Seconds()
+		total += age
+		if age > oldest {
+			oldest = age
+		}
+	}
+	m.unfinishedWorkSeconds.Set(total)
+	m.longestRunningProcessor.Set(oldest)
+}
+
+func (m *queueMetrics) retry() {
+	m.retries.Inc()
+}
@@ -0,0 +1,401 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package priorityqueue provides a work queue for controllers which hands out
+// the items with the highest priority first, so that requests caused by users
+// aren't stuck behind the requests of the initial list of a source.
+//
+// It is used by controllers created with controller.Options.UsePriorityQueue.
+// Event handlers can set the priority of the requests they add with
+// AddWithPriority.
+package priorityqueue
+
+import (
+	"container/heap"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// DefaultPriority is the priority of the items added without one.
+	DefaultPriority = 0
+	// LowPriority is the priority of the items that are unlikely to need any
+	// work, like the requests for the initial list of a source, or for
+	// resyncs.
+	LowPriority = -100
+)
+
+var depth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Subsystem: metrics.WorkQueueSubsystem,
+	Name:      "depth_by_priority",
+	Help:      "Current depth of priority workqueue by priority",
+}, []string{"name", "priority"})
+
+func init() {
+	metrics.Registry.MustRegister(depth)
+}
+
+// AddOpts are the options of PriorityQueue.AddWithOpts.
+type AddOpts struct {
+	// After delays the addition of the items, like AddAfter.
+	After time.Duration
+	// RateLimited delays the addition of the items by the delay of the rate
+	// limiter of the queue, like AddRateLimited.  It takes precedence over
+	// After.
+	RateLimited bool
+	// Priority is the priority of the items.  Items with higher priorities
+	// are returned by Get first.
+	Priority int
+}
+
+// PriorityQueue is a workqueue.RateLimitingInterface whose items have
+// priorities.
+type PriorityQueue interface {
+	workqueue.RateLimitingInterface
+
+	// AddWithOpts adds items with the given options.  Items that are
+	// already queued keep the highest of their priorities.  Items that are
+	// already waiting to be added after a delay are added at the earliest
+	// of their times, with the highest of their priorities.
+	AddWithOpts(opts AddOpts, items ...interface{})
+}
+
+// AddWithPriority adds item to queue with the given priority if queue is a
+// PriorityQueue, and like Add otherwise.
+func AddWithPriority(queue workqueue.Interface, priority int, item interface{}) {
+	if pq, ok := queue.(PriorityQueue); ok {
+		pq.AddWithOpts(AddOpts{Priority: priority}, item)
+		return
+	}
+	queue.Add(item)
+}
+
+// New returns a new PriorityQueue, which limits the rate of the items with
+// rateLimiter.  Its name is used in metrics, which are the same as the ones
+// of the queues of the workqueue package.
+//
+// Like the queues of the workqueue package, an item is queued at most once,
+// and an item added while it is being processed is queued again once it is
+// Done.  Items requeued with AddRateLimited or AddAfter while being
+// processed keep the priority they were queued with.
+func New(name string, rateLimiter workqueue.RateLimiter) PriorityQueue {
+	q := &priorityQueue{
+		name:        name,
+		rateLimiter: rateLimiter,
+		metrics:     newQueueMetrics(metrics.WorkqueueMetricsProvider{}, name),
+		queued:      map[interface{}]*entry{},
+		processing:  map[interface{}]int{},
+		dirty:       map[interface{}]int{},
+		waiting:     map[interface{}]*waitingItem{},
+	}
+	q.cond = sync.NewCond(&q.mu)
+	go q.updateUnfinishedWorkLoop()
+	return q
+}
+
+type priorityQueue struct {
+	name        string
+	rateLimiter workqueue.RateLimiter
+
+	mu sync.Mutex
+	// metrics are the workqueue metrics of the queue.
+	metrics *queueMetrics
+	cond    *sync.Cond
+	// heap holds the entries of the queued items, ordered by priority.
+	heap entryHeap
+	// queued are the entries of the queued items.
+	queued map[interface{}]*entry
+	// processing are the items returned by Get, and not Done yet, with the
+	// priority they were queued with.
+	processing map[interface{}]int
+	// dirty are the items added while being processed, with their priority.
+	dirty map[interface{}]int
+	// waiting are the items waiting to be added after a delay.
+	waiting map[interface{}]*waitingItem
+	// added counts the items added, to return items of the same priority in
+	// the order they were added.
+	added        uint64
+	shuttingDown bool
+}
+
+var _ PriorityQueue = &priorityQueue{}
+
+// entry is a queued item.
+type entry struct {
+	item     interface{}
+	priority int
+	order    uint64
+	index    int
+}
+
+// waitingItem is an item waiting to be added after a delay.
+type waitingItem struct {
+	priority int
+	readyAt  time.Time
+	timer    *time.Timer
+}
+
+// entryHeap implements heap.Interface.
+type entryHeap []*entry
+
+func (h entryHeap) Len() int { return len(h) }
+
+func (h entryHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].order < h[j].order
+}
+
+func (h entryHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *entryHeap) Push(x interface{}) {
+	e := x.(*entry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *entryHeap) Pop() interface{} {
+	old := *h
+	e := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return e
+}
+
+// AddWithOpts implements PriorityQueue.
+func (q *priorityQueue) AddWithOpts(opts AddOpts, items ...interface{}) {
+	for _, item := range items {
+		delay := opts.After
+		if opts.RateLimited {
+			delay = q.rateLimiter.When(item)
+		}
+		q.mu.Lock()
+		if opts.RateLimited || opts.After > 0 {
+			q.metrics.retry()
+		}
+		if delay > 0 {
+			q.addAfter(item, opts.Priority, delay)
+		} else {
+			q.add(item, opts.Priority)
+		}
+		q.mu.Unlock()
+	}
+}
+
+// addAfter adds item once delay has passed.  If item is waiting already, it
+// is added at the earliest of the times, with the highest of the priorities.
+// It must be called with the lock held.
+func (q *priorityQueue) addAfter(item interface{}, priority int, delay time.Duration) {
+	if q.shuttingDown {
+		return
+	}
+
+	readyAt := time.Now().Add(delay)
+	if w, ok := q.waiting[item]; ok {
+		if priority > w.priority {
+			w.priority = priority
+		}
+		if readyAt.Before(w.readyAt) {
+			w.readyAt = readyAt
+			w.timer.Reset(delay)
+		}
+		return
+	}
+
+	w := &waitingItem{priority: priority, readyAt: readyAt}
+	w.timer = time.AfterFunc(delay, func() {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		// The timer might have been reset after it fired, or the item
+		// been added after the shutdown.
+		if q.waiting[item] != w {
+			return
+		}
+		delete(q.waiting, item)
+		q.add(item, w.priority)
+	})
+	q.waiting[item] = w
+}
+
+// add queues item, or raises its priority if it is queued already.  It must
+// be called with the lock held.
+func (q *priorityQueue) add(item interface{}, priority int) {
+	if q.shuttingDown {
+		return
+	}
+
+	if _, ok := q.processing[item]; ok {
+		p, ok := q.dirty[item]
+		if !ok {
+			q.metrics.add(item)
+		}
+		if !ok || priority > p {
+			q.dirty[item] = priority
+		}
+		return
+	}
+	if e, ok := q.queued[item]; ok {
+		if priority > e.priority {
+			q.updateDepth(e.priority, -1)
+			e.priority = priority
+			heap.Fix(&q.heap, e.index)
+			q.updateDepth(e.priority, 1)
+		}
+		return
+	}
+
+	q.metrics.add(item)
+	q.push(item, priority)
+}
+
+// push queues item, which mustn't be queued yet.
+func (q *priorityQueue) push(item interface{}, priority int) {
+	q.added++
+	e := &entry{item: item, priority: priority, order: q.added}
+	heap.Push(&q.heap, e)
+	q.queued[item] = e
+	q.updateDepth(priority, 1)
+	q.cond.Signal()
+}
+
+func (q *priorityQueue) updateDepth(priority int, delta float64) {
+	depth.WithLabelValues(q.name, strconv.Itoa(priority)).Add(delta)
+}
+
+// Add implements workqueue.Interface.
+func (q *priorityQueue) Add(item interface{}) {
+	q.AddWithOpts(AddOpts{}, item)
+}
+
+// AddAfter implements workqueue.DelayingInterface.
+func (q *priorityQueue) AddAfter(item interface{}, duration time.Duration) {
+	q.AddWithOpts(AddOpts{After: duration, Priority: q.requeuePriority(item)}, item)
+}
+
+// AddRateLimited implements workqueue.RateLimitingInterface.
+func (q *priorityQueue) AddRateLimited(item interface{}) {
+	q.AddWithOpts(AddOpts{RateLimited: true, Priority: q.requeuePriority(item)}, item)
+}
+
+// requeuePriority returns the priority item is being processed with, or the
+// default priority if it isn't being processed.
+func (q *priorityQueue) requeuePriority(item interface{}) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if priority, ok := q.processing[item]; ok {
+		return priority
+	}
+	return DefaultPriority
+}
+
+// Forget implements workqueue.RateLimitingInterface.
+func (q *priorityQueue) Forget(item interface{}) {
+	q.rateLimiter.Forget(item)
+}
+
+// NumRequeues implements workqueue.RateLimitingInterface.
+func (q *priorityQueue) NumRequeues(item interface{}) int {
+	return q.rateLimiter.NumRequeues(item)
+}
+
+// Len implements workqueue.Interface.
+func (q *priorityQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.heap)
+}
+
+// Get implements workqueue.Interface.  It blocks until an item is queued,
+// and returns the one with the highest priority.
+func (q *priorityQueue) Get() (interface{}, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.heap) == 0 && !q.shuttingDown {
+		q.cond.Wait()
+	}
+	if len(q.heap) == 0 {
+		return nil, true
+	}
+
+	e := heap.Pop(&q.heap).(*entry)
+	delete(q.queued, e.item)
+	q.updateDepth(e.priority, -1)
+	q.metrics.get(e.item)
+	q.processing[e.item] = e.priority
+	return e.item, false
+}
+
+// Done implements workqueue.Interface.
+func (q *priorityQueue) Done(item interface{}) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.metrics.done(item)
+	delete(q.processing, item)
+	if priority, ok := q.dirty[item]; ok {
+		delete(q.dirty, item)
+		q.push(item, priority)
+	}
+}
+
+// ShutDown implements workqueue.Interface.  Items already queued are still
+// returned by Get, like with the queues of the workqueue package, while the
+// items waiting to be added are dropped.
+func (q *priorityQueue) ShutDown() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.shuttingDown = true
+	for item, w := range q.waiting {
+		w.timer.Stop()
+		delete(q.waiting, item)
+	}
+	q.cond.Broadcast()
+}
+
+// ShuttingDown implements workqueue.Interface.
+func (q *priorityQueue) ShuttingDown() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.shuttingDown
+}
+
+// updateUnfinishedWorkLoop updates the metrics of the work in progress
+// periodically until the queue is shut down.
+func (q *priorityQueue) updateUnfinishedWorkLoop() {
+	ticker := time.NewTicker(unfinishedWorkUpdatePeriod)
+	defer ticker.Stop()
+	for range ticker.C {
+		q.mu.Lock()
+		shuttingDown := q.shuttingDown
+		if !shuttingDown {
+			q.metrics.updateUnfinishedWork()
+		}
+		q.mu.Unlock()
+		if shuttingDown {
+			return
+		}
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priorityqueue_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var _ = Describe("PriorityQueue", func() {
+	var q priorityqueue.PriorityQueue
+	var name string
+
+	BeforeEach(func() {
+		name = CurrentGinkgoTestDescription().TestText
+		q = priorityqueue.New(name, workqueue.NewItemExponentialFailureRateLimiter(10*time.Millisecond, time.Second))
+	})
+
+	AfterEach(func() {
+		q.ShutDown()
+	})
+
+	get := func() interface{} {
+		item, shutdown := q.Get()
+		Expect(shutdown).To(BeFalse())
+		q.Done(item)
+		return item
+	}
+
+	It("should return items by priority, and in the order they were added within a priority", func() {
+		q.Add("a")
+		q.AddWithOpts(priorityqueue.AddOpts{Priority: priorityqueue.LowPriority}, "low")
+		q.AddWithOpts(priorityqueue.AddOpts{Priority: 10}, "high")
+		q.Add("b")
+
+		Expect(q.Len()).To(Equal(4))
+		Expect([]interface{}{get(), get(), get(), get()}).To(Equal([]interface{}{"high", "a", "b", "low"}))
+		Expect(q.Len()).To(Equal(0))
+	})
+
+	It("should queue an item once, with the highest of its priorities", func() {
+		q.Add("a")
+		q.AddWithOpts(priorityqueue.AddOpts{Priority: priorityqueue.LowPriority}, "b")
+		q.AddWithOpts(priorityqueue.AddOpts{Priority: priorityqueue.LowPriority}, "a")
+		q.AddWithOpts(priorityqueue.AddOpts{Priority: 10}, "b")
+
+		Expect(q.Len()).To(Equal(2))
+		Expect([]interface{}{get(), get()}).To(Equal([]interface{}{"b", "a"}))
+	})
+
+	It("should queue an item added while being processed once it is done", func() {
+		q.Add("a")
+		item, _ := q.Get()
+		Expect(item).To(Equal("a"))
+
+		q.AddWithOpts(priorityqueue.AddOpts{Priority: priorityqueue.LowPriority}, "a")
+		q.AddWithOpts(priorityqueue.AddOpts{Priority: 10}, "a")
+		q.Add("b")
+		Expect(q.Len()).To(Equal(1))
+
+		q.Done("a")
+		Expect(q.Len()).To(Equal(2))
+		Expect([]interface{}{get(), get()}).To(Equal([]interface{}{"a", "b"}))
+	})
+
+	It("should delay items added after a duration", func() {
+		q.AddAfter("a", 50*time.Millisecond)
+		Expect(q.Len()).To(Equal(0))
+		Eventually(q.Len).Should(Equal(1))
+		Expect(get()).To(Equal("a"))
+	})
+
+	It("should delay items added rate limited with the rate limiter", func() {
+		q.AddRateLimited("a")
+		q.AddRateLimited("a")
+		Expect(q.NumRequeues("a")).To(Equal(2))
+		Expect(q.Len()).To(Equal(0))
+		Eventually(q.Len).Should(Equal(1))
+		Expect(get()).To(Equal("a"))
+
+		q.Forget("a")
+		Expect(q.NumRequeues("a")).To(Equal(0))
+	})
+
+	It("should add an item waiting to be added once, at the earliest time and with the highest priority", func() {
+		q.AddWithOpts(priorityqueue.AddOpts{After: time.Hour, Priority: 10}, "a")
+		q.AddAfter("a", 10*time.Millisecond)
+		q.Add("b")
+
+		Eventually(q.Len).Should(Equal(2))
+		Expect([]interface{}{get(), get()}).To(Equal([]interface{}{"a", "b"}))
+		Consistently(q.Len, 50*time.Millisecond).Should(Equal(0))
+	})
+
+	It("should keep the priority of the items requeued while being processed", func() {
+		q.AddWithOpts(priorityqueue.AddOpts{Priority: 10}, "a")
+		item, _ := q.Get()
+		Expect(item).To(Equal("a"))
+		q.AddRateLimited("a")
+		q.Done("a")
+
+		q.Add("b")
+		Eventually(q.Len).Should(Equal(2))
+		Expect([]interface{}{get(), get()}).To(Equal([]interface{}{"a", "b"}))
+	})
+
+	It("should return the items left and then shutdown once shut down", func() {
+		q.Add("a")
+		q.ShutDown()
+		Expect(q.ShuttingDown()).To(BeTrue())
+
+		q.Add("b")
+		Expect(get()).To(Equal("a"))
+		_, shutdown := q.Get()
+		Expect(shutdown).To(BeTrue())
+	})
+
+	It("should export the depth of the queue by priority", func() {
+		q.Add("a")
+		q.Add("b")
+		q.AddWithOpts(priorityqueue.AddOpts{Priority: priorityqueue.LowPriority}, "c")
+		q.AddWithOpts(priorityqueue.AddOpts{Priority: 10}, "c")
+
+		Expect(depthOf(name, "-100")).To(Equal(0.0))
+		Expect(depthOf(name, "0")).To(Equal(2.0))
+		Expect(depthOf(name, "10")).To(Equal(1.0))
+
+		get()
+		Expect(depthOf(name, "10")).To(Equal(0.0))
+		Expect(depthOf(name, "0")).To(Equal(2.0))
+	})
+
+	It("should export the metrics of the queues of the workqueue package", func() {
+		q.Add("a")
+		q.Add("b")
+		q.AddRateLimited("c")
+		Expect(workqueueMetric("workqueue_depth", name)).To(Equal(2.0))
+		Expect(workqueueMetric("workqueue_adds_total", name)).To(Equal(2.0))
+		Expect(workqueueMetric("workqueue_retries_total", name)).To(Equal(1.0))
+
+		get()
+		Expect(workqueueMetric("workqueue_depth", name)).To(Equal(1.0))
+		Expect(workqueueMetric("workqueue_queue_duration_seconds", name)).To(Equal(1.0))
+		Expect(workqueueMetric("workqueue_work_duration_seconds", name)).To(Equal(1.0))
+		Eventually(q.Len).Should(Equal(2))
+		Expect(workqueueMetric("workqueue_adds_total", name)).To(Equal(3.0))
+
+		item, _ := q.Get()
+		Eventually(func() float64 {
+			return workqueueMetric("workqueue_longest_running_processor_seconds", name)
+		}).Should(BeNumerically(">", 0))
+		Expect(workqueueMetric("workqueue_unfinished_work_seconds", name)).To(BeNumerically(">", 0))
+		q.Done(item)
+	})
+
+	It("should add items with a priority through AddWithPriority", func() {
+		priorityqueue.AddWithPriority(q, priorityqueue.LowPriority, "low")
+		q.Add("a")
+		Expect([]interface{}{get(), get()}).To(Equal([]interface{}{"a", "low"}))
+
+		plain := workqueue.New()
+		defer plain.ShutDown()
+		priorityqueue.AddWithPriority(plain, priorityqueue.LowPriority, "a")
+		Expect(plain.Len()).To(Equal(1))
+	})
+})
+
+// depthOf returns the depth of the queue with the given name for a priority.
+func depthOf(name, priority string) float64 {
+	families, err := metrics.Registry.Gather()
+	Expect(err).NotTo(HaveOccurred())
+	for _, family := range families {
+		if family.GetName() != "workqueue_depth_by_priority" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["name"] == name && labels["priority"] == priority {
+				return m.GetGauge().GetValue()
+			}
+		}
+	}
+	Fail("no depth for queue " + name + " and priority " + priority)
+	return 0
+}
+
+// workqueueMetric returns the value of a workqueue metric for the queue with
+// the given name, or the number of observations for histograms.
+func workqueueMetric(family, name string) float64 {
+	families, err := metrics.Registry.Gather()
+	Expect(err).NotTo(HaveOccurred())
+	for _, f := range families {
+		if f.GetName() != family {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() != "name" || l.GetValue() != name {
+					continue
+				}
+				switch {
+				case m.Gauge != nil:
+					return m.GetGauge().GetValue()
+				case m.Counter != nil:
+					return m.GetCounter().GetValue()
+				case m.Histogram != nil:
+					return float64(m.GetHistogram().GetSampleCount())
+				}
+			}
+		}
+	}
+	return 0
+}
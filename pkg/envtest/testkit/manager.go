@@ -0,0 +1,195 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testkit helps writing integration tests of controllers, usually
+// against the API server of an envtest.Environment, with less ad-hoc polling
+// for the effects of the controllers.
+//
+// Its Manager tracks the work of the controllers added to it, and
+// WaitForIdle waits until they look done:
+//
+//	mgr, err := testkit.NewManager(testEnv.Config, testkit.Options{})
+//	... add controllers to mgr, e.g. with builder.ControllerManagedBy(mgr) ...
+//	go mgr.Start(ctx)
+//
+//	... make changes ...
+//	Expect(mgr.WaitForIdle(ctx)).To(Succeed())
+//	... check the effects of the controllers ...
+//
+// WaitForIdle is not deterministic.  It only sees the requests in the queues
+// of the controllers, and doesn't know whether the caches have observed the
+// writes made so far, so it assumes that the events caused by the last
+// changes reach the queues within a quiet period.  It replaces most of the
+// polling in tests whose controllers react quickly, but doesn't guarantee
+// that their effects are complete when it returns: checks of effects that
+// might take longer, or that depend on other runnables of the manager, still
+// need to poll, e.g. with Gomega's Eventually.
+package testkit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/internal/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	defaultQuietPeriod = 100 * time.Millisecond
+	pollInterval       = 10 * time.Millisecond
+)
+
+// Options are the arguments for creating a new Manager.
+type Options struct {
+	// Manager are the options of the underlying manager.
+	Manager manager.Options
+
+	// QuietPeriod is how long the controllers have to stay idle for
+	// WaitForIdle to return.  It leaves time for the events caused by the
+	// last changes to reach the caches, and so the queues of the
+	// controllers; WaitForIdle returns too early for events that take
+	// longer.  Defaults to 100ms.
+	QuietPeriod time.Duration
+}
+
+// Manager is a manager.Manager which tracks the work of the controllers
+// added to it.
+type Manager struct {
+	manager.Manager
+
+	quietPeriod time.Duration
+
+	mu          sync.Mutex
+	controllers []*trackedController
+}
+
+// trackedController is a controller added to a Manager.
+type trackedController struct {
+	name string
+
+	mu sync.Mutex
+	// queue is the queue of the controller, nil until it is started.
+	queue *trackingQueue
+}
+
+func (c *trackedController) idle() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.queue != nil && c.queue.idle()
+}
+
+var _ manager.Manager = &Manager{}
+
+// NewManager returns a new Manager for the given config, usually the config
+// of an envtest.Environment.
+func NewManager(config *rest.Config, options Options) (*Manager, error) {
+	mgr, err := manager.New(config, options.Manager)
+	if err != nil {
+		return nil, err
+	}
+	if options.QuietPeriod <= 0 {
+		options.QuietPeriod = defaultQuietPeriod
+	}
+	return &Manager{Manager: mgr, quietPeriod: options.QuietPeriod}, nil
+}
+
+// Add implements manager.Manager.  The controllers added, like the ones
+// created with controller.New or the builder package, are tracked by
+// WaitForIdle.  They must not have been started yet.  Controllers of other
+// requests than reconcile.Requests, created with controller.NewTyped, aren't
+// tracked.
+func (m *Manager) Add(r manager.Runnable) error {
+	if c, ok := r.(*controller.Controller); ok {
+		m.track(c)
+	}
+	return m.Manager.Add(r)
+}
+
+// track replaces the queue of c with a queue which tracks its work.
+func (m *Manager) track(c *controller.Controller) {
+	tracked := &trackedController{name: c.Name}
+	makeQueue, rateLimiter := c.MakeQueue, c.RateLimiter
+	if rateLimiter == nil {
+		rateLimiter = workqueue.DefaultControllerRateLimiter()
+	}
+	c.MakeQueue = func() workqueue.RateLimitingInterface {
+		queue := newTrackingQueue(makeQueue(), rateLimiter)
+		tracked.mu.Lock()
+		defer tracked.mu.Unlock()
+		tracked.queue = queue
+		return queue
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.controllers = append(m.controllers, tracked)
+}
+
+// busyControllers returns the names of the controllers that aren't idle.
+func (m *Manager) busyControllers() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var busy []string
+	for _, c := range m.controllers {
+		if !c.idle() {
+			busy = append(busy, c.name)
+		}
+	}
+	return busy
+}
+
+// WaitForIdle blocks until all the controllers of the manager have been
+// started and have stayed idle for the quiet period: their queues are
+// empty, no request is being reconciled and no request is waiting to be
+// requeued, after an error or a RequeueAfter.  This means that controllers
+// that requeue requests periodically are never idle.
+//
+// Idleness is polled, and doesn't account for events still on their way
+// from the API server to the queues, nor for the work of runnables other
+// than controllers.  It is a heuristic, not a guarantee: WaitForIdle returns
+// before the effects of a change are complete if its events take longer than
+// the quiet period to reach the queues.
+//
+// WaitForIdle returns an error, with the names of the controllers still
+// busy, if ctx is done first.
+func (m *Manager) WaitForIdle(ctx context.Context) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var idleSince time.Time
+	for {
+		busy := m.busyControllers()
+		switch {
+		case len(busy) > 0:
+			idleSince = time.Time{}
+		case idleSince.IsZero():
+			idleSince = time.Now()
+		case time.Since(idleSince) >= m.quietPeriod:
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("controllers %v are not idle: %w", busy, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
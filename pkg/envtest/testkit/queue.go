@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testkit
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+)
+
+// trackingQueue is the queue of a controller, which keeps track of the
+// requests being reconciled and of the requests waiting to be requeued.
+//
+// The requests waiting to be requeued are kept by trackingQueue itself,
+// instead of the delaying queue, so that it knows when they are added back.
+// It implements priorityqueue.PriorityQueue, so that the priorities given by
+// event handlers reach the queue of the controller if it is a priority queue.
+type trackingQueue struct {
+	workqueue.RateLimitingInterface
+	rateLimiter workqueue.RateLimiter
+
+	mu sync.Mutex
+	// processing is the number of requests returned by Get and not Done yet.
+	processing int
+	// waiting is the number of requests waiting to be added after a delay.
+	waiting int
+}
+
+var _ priorityqueue.PriorityQueue = &trackingQueue{}
+
+func newTrackingQueue(queue workqueue.RateLimitingInterface, rateLimiter workqueue.RateLimiter) *trackingQueue {
+	return &trackingQueue{RateLimitingInterface: queue, rateLimiter: rateLimiter}
+}
+
+// Get implements workqueue.Interface.
+func (q *trackingQueue) Get() (interface{}, bool) {
+	item, shutdown := q.RateLimitingInterface.Get()
+	if !shutdown {
+		q.mu.Lock()
+		q.processing++
+		q.mu.Unlock()
+	}
+	return item, shutdown
+}
+
+// Done implements workqueue.Interface.
+func (q *trackingQueue) Done(item interface{}) {
+	// Done adds the item back to the queue if it was added while being
+	// processed, so the queue isn't idle in between.
+	q.RateLimitingInterface.Done(item)
+	q.mu.Lock()
+	q.processing--
+	q.mu.Unlock()
+}
+
+// AddWithOpts implements priorityqueue.PriorityQueue.  The priority is
+// ignored if the queue of the controller isn't a priority queue.
+func (q *trackingQueue) AddWithOpts(opts priorityqueue.AddOpts, items ...interface{}) {
+	for _, item := range items {
+		delay := opts.After
+		if opts.RateLimited {
+			delay = q.rateLimiter.When(item)
+		}
+		q.addAfter(item, opts.Priority, delay)
+	}
+}
+
+// AddAfter implements workqueue.DelayingInterface.
+func (q *trackingQueue) AddAfter(item interface{}, duration time.Duration) {
+	q.addAfter(item, priorityqueue.DefaultPriority, duration)
+}
+
+// AddRateLimited implements workqueue.RateLimitingInterface.
+func (q *trackingQueue) AddRateLimited(item interface{}) {
+	q.AddAfter(item, q.rateLimiter.When(item))
+}
+
+func (q *trackingQueue) addAfter(item interface{}, priority int, duration time.Duration) {
+	if duration <= 0 {
+		priorityqueue.AddWithPriority(q.RateLimitingInterface, priority, item)
+		return
+	}
+	q.mu.Lock()
+	q.waiting++
+	q.mu.Unlock()
+	time.AfterFunc(duration, func() {
+		priorityqueue.AddWithPriority(q.RateLimitingInterface, priority, item)
+		q.mu.Lock()
+		q.waiting--
+		q.mu.Unlock()
+	})
+}
+
+// Forget implements workqueue.RateLimitingInterface.
+func (q *trackingQueue) Forget(item interface{}) {
+	q.rateLimiter.Forget(item)
+}
+
+// NumRequeues implements workqueue.RateLimitingInterface.
+func (q *trackingQueue) NumRequeues(item interface{}) int {
+	return q.rateLimiter.NumRequeues(item)
+}
+
+// idle returns whether the queue is empty, no request is being reconciled
+// and no request is waiting to be requeued.
+func (q *trackingQueue) idle() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.processing == 0 && q.waiting == 0 && q.Len() == 0
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testkit
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Manager", func() {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		cl     client.Client
+		mgr    *Manager
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		// The manager runs on a fake client and cache, the way it would run
+		// on the config of an envtest.Environment.
+		var c cache.Cache
+		cl, c = fake.NewClientBuilder().WithObjects(
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"}},
+		).BuildWithCache()
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+		var err error
+		mgr, err = NewManager(&rest.Config{}, Options{Manager: manager.Options{
+			Scheme:             scheme.Scheme,
+			MetricsBindAddress: "0",
+			MapperProvider:     func(*rest.Config) (meta.RESTMapper, error) { return mapper, nil },
+			NewCache:           func(*rest.Config, cache.Options) (cache.Cache, error) { return c, nil },
+			NewClient: func(cache.Cache, *rest.Config, client.Options, ...client.Object) (client.Client, error) {
+				return cl, nil
+			},
+		}})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	start := func() {
+		go func() {
+			defer GinkgoRecover()
+			Expect(mgr.Start(ctx)).To(Succeed())
+		}()
+	}
+
+	fastRetries := controller.Options{RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond, 10*time.Millisecond)}
+
+	It("should wait for the reconciles caused by changes to settle", func() {
+		By("Labeling the configmaps, in two steps")
+		err := builder.ControllerManagedBy(mgr).
+			For(&corev1.ConfigMap{}).
+			Complete(reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+				obj := &corev1.ConfigMap{}
+				if err := cl.Get(ctx, req.NamespacedName, obj); err != nil {
+					return reconcile.Result{}, client.IgnoreNotFound(err)
+				}
+				switch obj.Labels["step"] {
+				case "":
+					obj.Labels = map[string]string{"step": "1"}
+				case "1":
+					obj.Labels["step"] = "2"
+				default:
+					return reconcile.Result{}, nil
+				}
+				return reconcile.Result{}, cl.Update(ctx, obj)
+			}))
+		Expect(err).NotTo(HaveOccurred())
+		start()
+
+		Expect(mgr.WaitForIdle(ctx)).To(Succeed())
+		obj := &corev1.ConfigMap{}
+		Expect(cl.Get(ctx, client.ObjectKey{Name: "cm", Namespace: "ns"}, obj)).To(Succeed())
+		Expect(obj.Labels).To(HaveKeyWithValue("step", "2"))
+
+		By("Creating a configmap")
+		obj = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "created", Namespace: "ns"}}
+		Expect(cl.Create(ctx, obj)).To(Succeed())
+		Expect(mgr.WaitForIdle(ctx)).To(Succeed())
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+		Expect(obj.Labels).To(HaveKeyWithValue("step", "2"))
+	})
+
+	It("should wait for the requests requeued after errors", func() {
+		var calls int32
+		err := builder.ControllerManagedBy(mgr).
+			For(&corev1.ConfigMap{}).
+			WithOptions(fastRetries).
+			Complete(reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				if atomic.AddInt32(&calls, 1) < 5 {
+					return reconcile.Result{}, errors.New("not yet")
+				}
+				return reconcile.Result{}, nil
+			}))
+		Expect(err).NotTo(HaveOccurred())
+		start()
+
+		Expect(mgr.WaitForIdle(ctx)).To(Succeed())
+		Expect(atomic.LoadInt32(&calls)).To(BeEquivalentTo(5))
+	})
+
+	It("should fail if the controllers don't become idle in time", func() {
+		err := builder.ControllerManagedBy(mgr).
+			Named("periodic").
+			For(&corev1.ConfigMap{}).
+			Complete(reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{RequeueAfter: 5 * time.Millisecond}, nil
+			}))
+		Expect(err).NotTo(HaveOccurred())
+		start()
+
+		waitCtx, waitCancel := context.WithTimeout(ctx, 300*time.Millisecond)
+		defer waitCancel()
+		Expect(mgr.WaitForIdle(waitCtx)).To(MatchError(ContainSubstring("controllers [periodic] are not idle")))
+	})
+
+	It("should not be idle before the controllers are started", func() {
+		err := builder.ControllerManagedBy(mgr).
+			Named("unstarted").
+			For(&corev1.ConfigMap{}).
+			Complete(reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{}, nil
+			}))
+		Expect(err).NotTo(HaveOccurred())
+
+		waitCtx, waitCancel := context.WithTimeout(ctx, 200*time.Millisecond)
+		defer waitCancel()
+		Expect(mgr.WaitForIdle(waitCtx)).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("trackingQueue", func() {
+	It("should track the requests being processed and waiting", func() {
+		rateLimiter := workqueue.NewItemExponentialFailureRateLimiter(10*time.Millisecond, time.Second)
+		q := newTrackingQueue(workqueue.NewRateLimitingQueue(rateLimiter), rateLimiter)
+		defer q.ShutDown()
+		Expect(q.idle()).To(BeTrue())
+
+		q.Add("item")
+		Expect(q.idle()).To(BeFalse())
+		item, _ := q.Get()
+		Expect(q.idle()).To(BeFalse())
+
+		By("Requeuing the item after an error")
+		q.AddRateLimited(item)
+		Expect(q.NumRequeues(item)).To(Equal(1))
+		q.Done(item)
+		Expect(q.Len()).To(Equal(0))
+		Expect(q.idle()).To(BeFalse())
+		Eventually(q.Len).Should(Equal(1))
+
+		item, _ = q.Get()
+		q.Forget(item)
+		Expect(q.NumRequeues(item)).To(Equal(0))
+		q.Done(item)
+		Expect(q.idle()).To(BeTrue())
+	})
+
+	It("should add the requests with their priorities to a priority queue", func() {
+		rateLimiter := workqueue.NewItemExponentialFailureRateLimiter(10*time.Millisecond, time.Second)
+		q := newTrackingQueue(priorityqueue.New("testkit", rateLimiter), rateLimiter)
+		defer q.ShutDown()
+
+		q.Add("default")
+		priorityqueue.AddWithPriority(q, 10, "high")
+		q.AddWithOpts(priorityqueue.AddOpts{After: 10 * time.Millisecond, Priority: 20}, "later")
+		Expect(q.idle()).To(BeFalse())
+		Eventually(q.Len).Should(Equal(3))
+
+		var items []interface{}
+		for q.Len() > 0 {
+			item, _ := q.Get()
+			items = append(items, item)
+			q.Done(item)
+		}
+		Expect(items).To(Equal([]interface{}{"later", "high", "default"}))
+		Expect(q.idle()).To(BeTrue())
+	})
+})
@@ -20,36 +20,57 @@ import "sigs.k8s.io/controller-runtime/pkg/client"
 
 // CreateEvent is an event where a Kubernetes object was created.  CreateEvent should be generated
 // by a source.Source and transformed into a reconcile.Request by an handler.EventHandler.
-type CreateEvent struct {
-	// Object is the object from the event
-	Object client.Object
-}
+type CreateEvent = TypedCreateEvent[client.Object]
 
 // UpdateEvent is an event where a Kubernetes object was updated.  UpdateEvent should be generated
 // by a source.Source and transformed into a reconcile.Request by an handler.EventHandler.
-type UpdateEvent struct {
+type UpdateEvent = TypedUpdateEvent[client.Object]
+
+// DeleteEvent is an event where a Kubernetes object was deleted.  DeleteEvent should be generated
+// by a source.Source and transformed into a reconcile.Request by an handler.EventHandler.
+type DeleteEvent = TypedDeleteEvent[client.Object]
+
+// GenericEvent is an event where the operation type is unknown (e.g. polling or event originating outside the cluster).
+// GenericEvent should be generated by a source.Source and transformed into a reconcile.Request by an
+// handler.EventHandler.
+type GenericEvent = TypedGenericEvent[client.Object]
+
+// TypedCreateEvent is an event where an object was created.  TypedCreateEvent should be generated
+// by a source.TypedSource and transformed into requests by an handler.TypedEventHandler.
+type TypedCreateEvent[object any] struct {
+	// Object is the object from the event
+	Object object
+
+	// IsInInitialList is true if the object was part of the initial list of
+	// the source, as opposed to created after the source was started.
+	IsInInitialList bool
+}
+
+// TypedUpdateEvent is an event where an object was updated.  TypedUpdateEvent should be generated
+// by a source.TypedSource and transformed into requests by an handler.TypedEventHandler.
+type TypedUpdateEvent[object any] struct {
 	// ObjectOld is the object from the event
-	ObjectOld client.Object
+	ObjectOld object
 
 	// ObjectNew is the object from the event
-	ObjectNew client.Object
+	ObjectNew object
 }
 
-// DeleteEvent is an event where a Kubernetes object was deleted.  DeleteEvent should be generated
-// by a source.Source and transformed into a reconcile.Request by an handler.EventHandler.
-type DeleteEvent struct {
+// TypedDeleteEvent is an event where an object was deleted.  TypedDeleteEvent should be generated
+// by a source.TypedSource and transformed into requests by an handler.TypedEventHandler.
+type TypedDeleteEvent[object any] struct {
 	// Object is the object from the event
-	Object client.Object
+	Object object
 
 	// DeleteStateUnknown is true if the Delete event was missed but we identified the object
 	// as having been deleted.
 	DeleteStateUnknown bool
 }
 
-// GenericEvent is an event where the operation type is unknown (e.g. polling or event originating outside the cluster).
-// GenericEvent should be generated by a source.Source and transformed into a reconcile.Request by an
-// handler.EventHandler.
-type GenericEvent struct {
+// TypedGenericEvent is an event where the operation type is unknown (e.g. polling or event originating outside the cluster).
+// TypedGenericEvent should be generated by a source.TypedSource and transformed into requests by an
+// handler.TypedEventHandler.
+type TypedGenericEvent[object any] struct {
 	// Object is the object from the event
-	Object client.Object
+	Object object
 }
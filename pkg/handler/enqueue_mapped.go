@@ -26,7 +26,11 @@ import (
 
 // MapFunc is the signature required for enqueueing requests from a generic function.
 // This type is usually used with EnqueueRequestsFromMapFunc when registering an event handler.
-type MapFunc func(client.Object) []reconcile.Request
+type MapFunc = TypedMapFunc[client.Object, reconcile.Request]
+
+// TypedMapFunc is the signature required for enqueueing requests of type request from objects of type object.
+// This type is usually used with TypedEnqueueRequestsFromMapFunc when registering an event handler.
+type TypedMapFunc[object any, request comparable] func(object) []request
 
 // EnqueueRequestsFromMapFunc enqueues Requests by running a transformation function that outputs a collection
 // of reconcile.Requests on each Event.  The reconcile.Requests may be for an arbitrary set of objects
@@ -39,45 +43,51 @@ type MapFunc func(client.Object) []reconcile.Request
 // For UpdateEvents which contain both a new and old object, the transformation function is run on both
 // objects and both sets of Requests are enqueue.
 func EnqueueRequestsFromMapFunc(fn MapFunc) EventHandler {
-	return &enqueueRequestsFromMapFunc{
+	return TypedEnqueueRequestsFromMapFunc(fn)
+}
+
+// TypedEnqueueRequestsFromMapFunc is EnqueueRequestsFromMapFunc for events for objects of type object, which
+// enqueues requests of type request.  It allows Controllers to reconcile requests other than reconcile.Request.
+func TypedEnqueueRequestsFromMapFunc[object any, request comparable](fn TypedMapFunc[object, request]) TypedEventHandler[object] {
+	return &enqueueRequestsFromMapFunc[object, request]{
 		toRequests: fn,
 	}
 }
 
-var _ EventHandler = &enqueueRequestsFromMapFunc{}
+var _ EventHandler = &enqueueRequestsFromMapFunc[client.Object, reconcile.Request]{}
 
-type enqueueRequestsFromMapFunc struct {
+type enqueueRequestsFromMapFunc[object any, request comparable] struct {
 	// Mapper transforms the argument into a slice of keys to be reconciled
-	toRequests MapFunc
+	toRequests TypedMapFunc[object, request]
 }
 
 // Create implements EventHandler
-func (e *enqueueRequestsFromMapFunc) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
-	reqs := map[reconcile.Request]empty{}
+func (e *enqueueRequestsFromMapFunc[object, request]) Create(evt event.TypedCreateEvent[object], q workqueue.RateLimitingInterface) {
+	reqs := map[request]empty{}
 	e.mapAndEnqueue(q, evt.Object, reqs)
 }
 
 // Update implements EventHandler
-func (e *enqueueRequestsFromMapFunc) Update(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
-	reqs := map[reconcile.Request]empty{}
+func (e *enqueueRequestsFromMapFunc[object, request]) Update(evt event.TypedUpdateEvent[object], q workqueue.RateLimitingInterface) {
+	reqs := map[request]empty{}
 	e.mapAndEnqueue(q, evt.ObjectOld, reqs)
 	e.mapAndEnqueue(q, evt.ObjectNew, reqs)
 }
 
 // Delete implements EventHandler
-func (e *enqueueRequestsFromMapFunc) Delete(evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
-	reqs := map[reconcile.Request]empty{}
+func (e *enqueueRequestsFromMapFunc[object, request]) Delete(evt event.TypedDeleteEvent[object], q workqueue.RateLimitingInterface) {
+	reqs := map[request]empty{}
 	e.mapAndEnqueue(q, evt.Object, reqs)
 }
 
 // Generic implements EventHandler
-func (e *enqueueRequestsFromMapFunc) Generic(evt event.GenericEvent, q workqueue.RateLimitingInterface) {
-	reqs := map[reconcile.Request]empty{}
+func (e *enqueueRequestsFromMapFunc[object, request]) Generic(evt event.TypedGenericEvent[object], q workqueue.RateLimitingInterface) {
+	reqs := map[request]empty{}
 	e.mapAndEnqueue(q, evt.Object, reqs)
 }
 
-func (e *enqueueRequestsFromMapFunc) mapAndEnqueue(q workqueue.RateLimitingInterface, object client.Object, reqs map[reconcile.Request]empty) {
-	for _, req := range e.toRequests(object) {
+func (e *enqueueRequestsFromMapFunc[object, request]) mapAndEnqueue(q workqueue.RateLimitingInterface, obj object, reqs map[request]empty) {
+	for _, req := range e.toRequests(obj) {
 		_, ok := reqs[req]
 		if !ok {
 			q.Add(req)
@@ -89,7 +99,7 @@ func (e *enqueueRequestsFromMapFunc) mapAndEnqueue(q workqueue.RateLimitingInter
 // EnqueueRequestsFromMapFunc can inject fields into the mapper.
 
 // InjectFunc implements inject.Injector.
-func (e *enqueueRequestsFromMapFunc) InjectFunc(f inject.Func) error {
+func (e *enqueueRequestsFromMapFunc[object, request]) InjectFunc(f inject.Func) error {
 	if f == nil {
 		return nil
 	}
@@ -184,6 +184,29 @@ var _ = Describe("Eventhandler", func() {
 	})
 
 	Describe("EnqueueRequestsFromMapFunc", func() {
+		It("should enqueue requests of the type returned by a typed function.", func() {
+			type clusterRequest struct {
+				Cluster string
+				reconcile.Request
+			}
+			instance := handler.TypedEnqueueRequestsFromMapFunc(func(a *corev1.Pod) []clusterRequest {
+				return []clusterRequest{{
+					Cluster: a.Spec.NodeName,
+					Request: reconcile.Request{NamespacedName: types.NamespacedName{Namespace: a.Namespace, Name: a.Name}},
+				}}
+			})
+
+			pod.Spec.NodeName = "cluster"
+			instance.Update(event.TypedUpdateEvent[*corev1.Pod]{ObjectOld: pod, ObjectNew: pod}, q)
+			Expect(q.Len()).To(Equal(1))
+
+			i, _ := q.Get()
+			Expect(i).To(Equal(clusterRequest{
+				Cluster: "cluster",
+				Request: reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "biz", Name: "baz"}},
+			}))
+		})
+
 		It("should enqueue a Request with the function applied to the CreateEvent.", func() {
 			req := []reconcile.Request{}
 			instance := handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"runtime/debug"
 	"sync"
 	"time"
 
@@ -38,7 +39,10 @@ import (
 var _ inject.Injector = &Controller{}
 
 // Controller implements controller.Controller
-type Controller struct {
+type Controller = TypedController[reconcile.Request]
+
+// TypedController implements controller.TypedController
+type TypedController[request comparable] struct {
 	// Name is used to uniquely identify a Controller in tracing, logging and monitoring.  Name is required.
 	Name string
 
@@ -48,13 +52,17 @@ type Controller struct {
 	// Reconciler is a function that can be called at any time with the Name / Namespace of an object and
 	// ensures that the state of the system matches the state specified in the object.
 	// Defaults to the DefaultReconcileFunc.
-	Do reconcile.Reconciler
+	Do reconcile.TypedReconciler[request]
 
 	// MakeQueue constructs the queue for this controller once the controller is ready to start.
 	// This exists because the standard Kubernetes workqueues start themselves immediately, which
 	// leads to goroutine leaks if something calls controller.New repeatedly.
 	MakeQueue func() workqueue.RateLimitingInterface
 
+	// RateLimiter is the rate limiter of the queue made by MakeQueue, if known.  It allows replacing
+	// MakeQueue with a queue that limits requests the same way, e.g. in tests.
+	RateLimiter workqueue.RateLimiter
+
 	// Queue is an listeningQueue that listens for events from Informers and adds object keys to
 	// the Queue for processing
 	Queue workqueue.RateLimitingInterface
@@ -85,6 +93,25 @@ type Controller struct {
 
 	// Log is used to log messages to users during reconciliation, or for example when a watch is started.
 	Log logr.Logger
+
+	// ReconcileTimeout is the deadline put on the context of each call to Do.Reconcile.  No deadline
+	// if zero.
+	ReconcileTimeout time.Duration
+
+	// RecoverPanic indicates whether panics in Do.Reconcile are recovered and turned into errors.
+	RecoverPanic bool
+
+	// PartitionKey, if set, maps requests to partitions, of which at most MaxConcurrentReconcilesPerPartition
+	// requests are reconciled at once.  Partitions with requests waiting are served in turn: the requests of
+	// partitions without room are taken out of the Queue to wait, so that they don't hold up other partitions.
+	PartitionKey func(req request) string
+
+	// MaxConcurrentReconcilesPerPartition is the maximum number of concurrent Reconciles per partition when
+	// PartitionKey is set.  Defaults to 1.
+	MaxConcurrentReconcilesPerPartition int
+
+	// partitions hands out the items of the Queue by partition when PartitionKey is set.
+	partitions *partitionedQueue
 }
 
 // watchDescription contains all the information necessary to start a watch.
@@ -95,14 +122,34 @@ type watchDescription struct {
 }
 
 // Reconcile implements reconcile.Reconciler
-func (c *Controller) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
-	log := c.Log.WithValues("name", req.Name, "namespace", req.Namespace)
+func (c *TypedController[request]) Reconcile(ctx context.Context, req request) (reconcile.Result, error) {
+	log := c.Log.WithValues(logValues(req)...)
 	ctx = logf.IntoContext(ctx, log)
+	return c.reconcile(ctx, req)
+}
+
+// reconcile calls Do.Reconcile with the ReconcileTimeout, recovering the panics if RecoverPanic is set.
+func (c *TypedController[request]) reconcile(ctx context.Context, req request) (_ reconcile.Result, err error) {
+	if c.RecoverPanic {
+		defer func() {
+			if r := recover(); r != nil {
+				ctrlmetrics.ReconcilePanics.WithLabelValues(c.Name).Inc()
+				err = fmt.Errorf("panic: %v [recovered]", r)
+				logf.FromContext(ctx).Error(err, "Observed a panic in reconciler", "stacktrace", string(debug.Stack()))
+			}
+		}()
+	}
+
+	if c.ReconcileTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.ReconcileTimeout)
+		defer cancel()
+	}
 	return c.Do.Reconcile(ctx, req)
 }
 
 // Watch implements controller.Controller
-func (c *Controller) Watch(src source.Source, evthdler handler.EventHandler, prct ...predicate.Predicate) error {
+func (c *TypedController[request]) Watch(src source.Source, evthdler handler.EventHandler, prct ...predicate.Predicate) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -132,7 +179,7 @@ func (c *Controller) Watch(src source.Source, evthdler handler.EventHandler, prc
 }
 
 // Start implements controller.Controller
-func (c *Controller) Start(ctx context.Context) error {
+func (c *TypedController[request]) Start(ctx context.Context) error {
 	// use an IIFE to get proper lock handling
 	// but lock outside to get proper handling of the queue shutdown
 	c.mu.Lock()
@@ -146,6 +193,13 @@ func (c *Controller) Start(ctx context.Context) error {
 	c.ctx = ctx
 
 	c.Queue = c.MakeQueue()
+	if c.PartitionKey != nil {
+		maxPerPartition := c.MaxConcurrentReconcilesPerPartition
+		if maxPerPartition <= 0 {
+			maxPerPartition = 1
+		}
+		c.partitions = newPartitionedQueue(c.Queue, c.partitionOf, maxPerPartition)
+	}
 	go func() {
 		<-ctx.Done()
 		c.Queue.ShutDown()
@@ -232,8 +286,16 @@ func (c *Controller) Start(ctx context.Context) error {
 
 // processNextWorkItem will read a single work item off the workqueue and
 // attempt to process it, by calling the reconcileHandler.
-func (c *Controller) processNextWorkItem(ctx context.Context) bool {
-	obj, shutdown := c.Queue.Get()
+func (c *TypedController[request]) processNextWorkItem(ctx context.Context) bool {
+	var work interface {
+		Get() (interface{}, bool)
+		Done(interface{})
+	} = c.Queue
+	if c.partitions != nil {
+		work = c.partitions
+	}
+
+	obj, shutdown := work.Get()
 	if shutdown {
 		// Stop working
 		return false
@@ -245,7 +307,7 @@ func (c *Controller) processNextWorkItem(ctx context.Context) bool {
 	// not call Forget if a transient error occurs, instead the item is
 	// put back on the workqueue and attempted again after a back-off
 	// period.
-	defer c.Queue.Done(obj)
+	defer work.Done(obj)
 
 	ctrlmetrics.ActiveWorkers.WithLabelValues(c.Name).Add(1)
 	defer ctrlmetrics.ActiveWorkers.WithLabelValues(c.Name).Add(-1)
@@ -261,9 +323,11 @@ const (
 	labelSuccess      = "success"
 )
 
-func (c *Controller) initMetrics() {
+func (c *TypedController[request]) initMetrics() {
 	ctrlmetrics.ActiveWorkers.WithLabelValues(c.Name).Set(0)
 	ctrlmetrics.ReconcileErrors.WithLabelValues(c.Name).Add(0)
+	ctrlmetrics.TerminalReconcileErrors.WithLabelValues(c.Name).Add(0)
+	ctrlmetrics.ReconcilePanics.WithLabelValues(c.Name).Add(0)
 	ctrlmetrics.ReconcileTotal.WithLabelValues(c.Name, labelError).Add(0)
 	ctrlmetrics.ReconcileTotal.WithLabelValues(c.Name, labelRequeueAfter).Add(0)
 	ctrlmetrics.ReconcileTotal.WithLabelValues(c.Name, labelRequeue).Add(0)
@@ -271,7 +335,7 @@ func (c *Controller) initMetrics() {
 	ctrlmetrics.WorkerCount.WithLabelValues(c.Name).Set(float64(c.MaxConcurrentReconciles))
 }
 
-func (c *Controller) reconcileHandler(ctx context.Context, obj interface{}) {
+func (c *TypedController[request]) reconcileHandler(ctx context.Context, obj interface{}) {
 	// Update metrics after processing each item
 	reconcileStartTS := time.Now()
 	defer func() {
@@ -279,7 +343,7 @@ func (c *Controller) reconcileHandler(ctx context.Context, obj interface{}) {
 	}()
 
 	// Make sure that the the object is a valid request.
-	req, ok := obj.(reconcile.Request)
+	req, ok := obj.(request)
 	if !ok {
 		// As the item in the workqueue is actually invalid, we call
 		// Forget here else we'd go into a loop of attempting to
@@ -290,15 +354,22 @@ func (c *Controller) reconcileHandler(ctx context.Context, obj interface{}) {
 		return
 	}
 
-	log := c.Log.WithValues("name", req.Name, "namespace", req.Namespace)
+	log := c.Log.WithValues(logValues(req)...)
 	ctx = logf.IntoContext(ctx, log)
 
 	// RunInformersAndControllers the syncHandler, passing it the Namespace/Name string of the
 	// resource to be synced.
-	if result, err := c.Do.Reconcile(ctx, req); err != nil {
-		c.Queue.AddRateLimited(req)
+	if result, err := c.reconcile(ctx, req); err != nil {
 		ctrlmetrics.ReconcileErrors.WithLabelValues(c.Name).Inc()
 		ctrlmetrics.ReconcileTotal.WithLabelValues(c.Name, labelError).Inc()
+		if reconcile.IsTerminalError(err) {
+			// Retrying won't help, so forget the request until the next event for the object.
+			c.Queue.Forget(obj)
+			ctrlmetrics.TerminalReconcileErrors.WithLabelValues(c.Name).Inc()
+			log.Error(err, "Reconciler error, not requeuing")
+			return
+		}
+		c.Queue.AddRateLimited(req)
 		log.Error(err, "Reconciler error")
 		return
 	} else if result.RequeueAfter > 0 {
@@ -323,18 +394,36 @@ func (c *Controller) reconcileHandler(ctx context.Context, obj interface{}) {
 	ctrlmetrics.ReconcileTotal.WithLabelValues(c.Name, labelSuccess).Inc()
 }
 
+// partitionOf returns the partition of a queue item.  Items which aren't requests all go to the same partition.
+func (c *TypedController[request]) partitionOf(obj interface{}) string {
+	req, ok := obj.(request)
+	if !ok {
+		return ""
+	}
+	return c.PartitionKey(req)
+}
+
+// logValues returns the keys and values identifying req in the logs: the name and namespace of the object
+// for reconcile.Requests, the request itself for other types.
+func logValues[request comparable](req request) []interface{} {
+	if r, ok := any(req).(reconcile.Request); ok {
+		return []interface{}{"name", r.Name, "namespace", r.Namespace}
+	}
+	return []interface{}{"request", req}
+}
+
 // GetLogger returns this controller's logger.
-func (c *Controller) GetLogger() logr.Logger {
+func (c *TypedController[request]) GetLogger() logr.Logger {
 	return c.Log
 }
 
 // InjectFunc implement SetFields.Injector
-func (c *Controller) InjectFunc(f inject.Func) error {
+func (c *TypedController[request]) InjectFunc(f inject.Func) error {
 	c.SetFields = f
 	return nil
 }
 
 // updateMetrics updates prometheus metrics within the controller
-func (c *Controller) updateMetrics(reconcileTime time.Duration) {
+func (c *TypedController[request]) updateMetrics(reconcileTime time.Duration) {
 	ctrlmetrics.ReconcileTime.WithLabelValues(c.Name).Observe(reconcileTime.Seconds())
 }
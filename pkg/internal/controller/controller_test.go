@@ -36,6 +36,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/internal/controller/metrics"
@@ -88,6 +89,34 @@ var _ = Describe("controller", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result).To(Equal(reconcile.Result{Requeue: true}))
 		})
+
+		It("should put the ReconcileTimeout on the context", func() {
+			ctrl.ReconcileTimeout = time.Minute
+			var deadline time.Time
+			ctrl.Do = reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				deadline, _ = ctx.Deadline()
+				return reconcile.Result{}, ctx.Err()
+			})
+			_, err := ctrl.Reconcile(context.Background(), request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Minute), 10*time.Second))
+		})
+
+		It("should not recover panics by default", func() {
+			ctrl.Do = reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				panic("test panic")
+			})
+			Expect(func() { _, _ = ctrl.Reconcile(context.Background(), request) }).To(PanicWith("test panic"))
+		})
+
+		It("should turn panics into errors if RecoverPanic is set", func() {
+			ctrl.RecoverPanic = true
+			ctrl.Do = reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				panic("test panic")
+			})
+			_, err := ctrl.Reconcile(context.Background(), request)
+			Expect(err).To(MatchError("panic: test panic [recovered]"))
+		})
 	})
 
 	Describe("Start", func() {
@@ -604,6 +633,153 @@ var _ = Describe("controller", func() {
 			Eventually(func() int { return dq.NumRequeues(request) }).Should(Equal(0))
 		})
 
+		It("should not requeue a Request if the error is a terminal error", func() {
+			dq := &DelegatingQueue{RateLimitingInterface: ctrl.MakeQueue()}
+			ctrl.MakeQueue = func() workqueue.RateLimitingInterface { return dq }
+			ctrlmetrics.TerminalReconcileErrors.Reset()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				defer GinkgoRecover()
+				Expect(ctrl.Start(ctx)).NotTo(HaveOccurred())
+			}()
+
+			dq.Add(request)
+			Expect(dq.getCounts()).To(Equal(countInfo{Trying: 1}))
+
+			By("Invoking Reconciler which will give a terminal error")
+			fakeReconcile.AddResult(reconcile.Result{}, reconcile.TerminalError(fmt.Errorf("expected error: invalid")))
+			Expect(<-reconciled).To(Equal(request))
+			Eventually(dq.getCounts).Should(Equal(countInfo{Trying: 0}))
+
+			By("Removing the item from the queue")
+			Eventually(dq.Len).Should(Equal(0))
+			Eventually(func() int { return dq.NumRequeues(request) }).Should(Equal(0))
+
+			var terminalErrs dto.Metric
+			Expect(ctrlmetrics.TerminalReconcileErrors.WithLabelValues(ctrl.Name).Write(&terminalErrs)).To(Succeed())
+			Expect(terminalErrs.GetCounter().GetValue()).To(Equal(1.0))
+		})
+
+		It("should requeue a Request with backoff after a recovered panic", func() {
+			dq := &DelegatingQueue{RateLimitingInterface: ctrl.MakeQueue()}
+			ctrl.MakeQueue = func() workqueue.RateLimitingInterface { return dq }
+			ctrl.RecoverPanic = true
+			ctrlmetrics.ReconcilePanics.Reset()
+			calls := make(chan struct{}, 2)
+			ctrl.Do = reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				calls <- struct{}{}
+				if len(calls) == 1 {
+					panic("test panic")
+				}
+				return reconcile.Result{}, nil
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				defer GinkgoRecover()
+				Expect(ctrl.Start(ctx)).NotTo(HaveOccurred())
+			}()
+
+			dq.Add(request)
+			By("Requeuing the request after the panic")
+			Eventually(calls).Should(HaveLen(2))
+			Eventually(dq.getCounts).Should(Equal(countInfo{AddRateLimited: 1}))
+
+			var panics dto.Metric
+			Expect(ctrlmetrics.ReconcilePanics.WithLabelValues(ctrl.Name).Write(&panics)).To(Succeed())
+			Expect(panics.GetCounter().GetValue()).To(Equal(1.0))
+		})
+
+		It("should not reconcile more Requests of a partition at once than the maximum", func() {
+			ctrl.MaxConcurrentReconciles = 3
+			ctrl.PartitionKey = func(req reconcile.Request) string { return req.Namespace }
+			started := make(chan reconcile.Request)
+			release := map[string]chan struct{}{"first": make(chan struct{}), "second": make(chan struct{}), "other": make(chan struct{})}
+			ctrl.Do = reconcile.Func(func(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+				started <- req
+				<-release[req.Name]
+				return reconcile.Result{}, nil
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			stopped := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				defer close(stopped)
+				Expect(ctrl.Start(ctx)).NotTo(HaveOccurred())
+			}()
+
+			first := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "foo", Name: "first"}}
+			second := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "foo", Name: "second"}}
+			other := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "bar", Name: "other"}}
+			queue.Add(first)
+			queue.Add(second)
+			queue.Add(other)
+
+			By("Reconciling a single Request per partition")
+			Eventually(started).Should(Receive(Equal(first)))
+			Eventually(started).Should(Receive(Equal(other)))
+			Consistently(started, 100*time.Millisecond).ShouldNot(Receive())
+
+			By("Reconciling the next Request of the partition once the first is done")
+			close(release["first"])
+			Eventually(started).Should(Receive(Equal(second)))
+			close(release["second"])
+			close(release["other"])
+
+			cancel()
+			Eventually(stopped).Should(BeClosed())
+		})
+
+		It("should reconcile a quiet partition before the backlog of a noisy one drains", func() {
+			pq := priorityqueue.New("partitioned-controller", workqueue.DefaultControllerRateLimiter())
+			ctrl.MakeQueue = func() workqueue.RateLimitingInterface { return pq }
+			ctrl.MaxConcurrentReconciles = 2
+			ctrl.PartitionKey = func(req reconcile.Request) string { return req.Namespace }
+			started := make(chan string, 100)
+			release := map[string]chan struct{}{"noisy-1": make(chan struct{}), "quiet": make(chan struct{})}
+			ctrl.Do = reconcile.Func(func(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+				started <- req.Name
+				if ch, ok := release[req.Name]; ok {
+					<-ch
+				}
+				return reconcile.Result{}, nil
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			stopped := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				defer close(stopped)
+				Expect(ctrl.Start(ctx)).NotTo(HaveOccurred())
+			}()
+
+			for i := 1; i <= 100; i++ {
+				pq.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "noisy", Name: fmt.Sprintf("noisy-%d", i)}})
+			}
+			priorityqueue.AddWithPriority(pq, priorityqueue.LowPriority,
+				reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "quiet", Name: "quiet"}})
+
+			By("Reconciling the quiet partition with the worker left")
+			Eventually(started).Should(Receive(Equal("noisy-1")))
+			Eventually(started).Should(Receive(Equal("quiet")))
+			Expect(pq.Len()).To(Equal(0))
+			Consistently(started, 100*time.Millisecond).ShouldNot(Receive())
+
+			By("Reconciling the backlog of the noisy partition in order")
+			close(release["noisy-1"])
+			for i := 2; i <= 100; i++ {
+				Eventually(started).Should(Receive(Equal(fmt.Sprintf("noisy-%d", i))))
+			}
+			close(release["quiet"])
+
+			cancel()
+			Eventually(stopped).Should(BeClosed())
+		})
+
 		PIt("should return if the queue is shutdown", func() {
 			// TODO(community): write this test
 		})
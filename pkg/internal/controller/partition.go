@@ -0,0 +1,144 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+// partitionedQueue hands out the items of a queue to the workers so that at most max items of each partition
+// are processed at once, and so that the partitions with items waiting are served in turn.
+//
+// Items are taken out of the queue whenever a worker is waiting and none of the items held can be handed out, and
+// held in a FIFO per partition until their partition has room.  The queue is drained this way as long as workers
+// are waiting, so that the items of a partition are never stuck behind the backlog of another one, however long.
+// Items keep the order the queue hands them out in, e.g. by priority for a priority queue, within their partition.
+// The queue sees the items held as being processed, so they aren't counted in its depth, and an item added again
+// while held is processed once more after it is done, like an item added while being processed.
+type partitionedQueue struct {
+	queue workqueue.Interface
+	key   func(item interface{}) string
+	max   int
+
+	mu   sync.Mutex
+	cond *sync.Cond
+	// held are the items taken out of the queue and waiting for room in their partition, by partition.
+	held map[string][]interface{}
+	// turns are the partitions with held items, in the order they are served.
+	turns []string
+	// active is the number of items being processed, by partition.
+	active map[string]int
+	// partitions are the partitions of the items being processed.
+	partitions map[interface{}]string
+	// taking is true while a worker is waiting for an item from the queue.
+	taking bool
+	// shutdown is true once the queue returned shutdown.
+	shutdown bool
+}
+
+func newPartitionedQueue(queue workqueue.Interface, key func(item interface{}) string, max int) *partitionedQueue {
+	q := &partitionedQueue{
+		queue:      queue,
+		key:        key,
+		max:        max,
+		held:       map[string][]interface{}{},
+		active:     map[string]int{},
+		partitions: map[interface{}]string{},
+	}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// Get blocks until an item whose partition has room is available, and returns it.  It returns shutdown once the
+// queue is shut down and all the items held were returned.
+func (q *partitionedQueue) Get() (item interface{}, shutdown bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for {
+		if item, ok := q.next(); ok {
+			return item, false
+		}
+		if q.shutdown && len(q.turns) == 0 {
+			return nil, true
+		}
+		if q.taking || q.shutdown {
+			// Wait for another worker to take an item from the queue, or for room in a partition.
+			q.cond.Wait()
+			continue
+		}
+
+		q.taking = true
+		q.mu.Unlock()
+		item, shutdown := q.queue.Get()
+		q.mu.Lock()
+		q.taking = false
+		if shutdown {
+			q.shutdown = true
+		} else {
+			q.hold(item)
+		}
+		q.cond.Broadcast()
+	}
+}
+
+// next returns the first held item of the first partition in turn that has room, and moves this partition to
+// the end of the turns.
+func (q *partitionedQueue) next() (interface{}, bool) {
+	for i, partition := range q.turns {
+		if q.active[partition] >= q.max {
+			continue
+		}
+		items := q.held[partition]
+		item := items[0]
+		q.turns = append(q.turns[:i:i], q.turns[i+1:]...)
+		if len(items) > 1 {
+			q.held[partition] = items[1:]
+			q.turns = append(q.turns, partition)
+		} else {
+			delete(q.held, partition)
+		}
+		q.active[partition]++
+		q.partitions[item] = partition
+		return item, true
+	}
+	return nil, false
+}
+
+// hold holds an item taken out of the queue until its partition has room.
+func (q *partitionedQueue) hold(item interface{}) {
+	partition := q.key(item)
+	if _, ok := q.held[partition]; !ok {
+		q.turns = append(q.turns, partition)
+	}
+	q.held[partition] = append(q.held[partition], item)
+}
+
+// Done marks an item returned by Get as done, making room in its partition.
+func (q *partitionedQueue) Done(item interface{}) {
+	q.mu.Lock()
+	partition := q.partitions[item]
+	delete(q.partitions, item)
+	if q.active[partition]--; q.active[partition] == 0 {
+		delete(q.active, partition)
+	}
+	q.cond.Broadcast()
+	q.mu.Unlock()
+
+	q.queue.Done(item)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// byFirstLetter partitions the items by their first letter.
+func byFirstLetter(item interface{}) string { return item.(string)[:1] }
+
+var _ = Describe("partitionedQueue", func() {
+	var queue workqueue.Interface
+	var q *partitionedQueue
+
+	BeforeEach(func() {
+		queue = workqueue.New()
+		q = newPartitionedQueue(queue, byFirstLetter, 1)
+	})
+
+	AfterEach(func() {
+		queue.ShutDown()
+	})
+
+	get := func() interface{} {
+		item, shutdown := q.Get()
+		Expect(shutdown).To(BeFalse())
+		return item
+	}
+
+	It("should not hand out more items of a partition than the maximum", func() {
+		queue.Add("a1")
+		queue.Add("a2")
+		queue.Add("b1")
+
+		Expect(get()).To(Equal("a1"))
+		Expect(get()).To(Equal("b1"))
+
+		By("Waiting for another item while a2 is held")
+		got := make(chan interface{})
+		go func() {
+			defer GinkgoRecover()
+			got <- get()
+		}()
+		Consistently(got, 100*time.Millisecond).ShouldNot(Receive())
+
+		By("Handing out a2 to the next worker once a1 is done")
+		q.Done("a1")
+		Expect(get()).To(Equal("a2"))
+		queue.Add("c1")
+		Eventually(got).Should(Receive(Equal("c1")))
+	})
+
+	It("should serve the partitions with items waiting in turn", func() {
+		for _, item := range []string{"a1", "a2", "a3", "b1", "b2", "c1"} {
+			queue.Add(item)
+		}
+
+		Expect(get()).To(Equal("a1"))
+		Expect(get()).To(Equal("b1"))
+		Expect(get()).To(Equal("c1"))
+		q.Done("a1")
+		q.Done("b1")
+		q.Done("c1")
+
+		By("Serving b before a again, even though a3 was queued before b2")
+		Expect(get()).To(Equal("a2"))
+		q.Done("a2")
+		Expect(get()).To(Equal("b2"))
+		q.Done("b2")
+		Expect(get()).To(Equal("a3"))
+	})
+
+	It("should hand out the items held before shutting down", func() {
+		queue.Add("a1")
+		queue.Add("a2")
+		Expect(get()).To(Equal("a1"))
+
+		got := make(chan interface{})
+		go func() {
+			defer GinkgoRecover()
+			got <- get()
+		}()
+		Eventually(queue.Len).Should(Equal(0))
+		queue.ShutDown()
+
+		q.Done("a1")
+		Eventually(got).Should(Receive(Equal("a2")))
+		q.Done("a2")
+		_, shutdown := q.Get()
+		Expect(shutdown).To(BeTrue())
+	})
+
+	It("should serve a quiet partition before the backlog of a noisy one", func() {
+		q = newPartitionedQueue(queue, byFirstLetter, 2)
+		for i := 1; i <= 1000; i++ {
+			queue.Add(fmt.Sprintf("a%d", i))
+		}
+		queue.Add("b1")
+
+		Expect(get()).To(Equal("a1"))
+		Expect(get()).To(Equal("a2"))
+		By("Handing out b1 to the next worker while a is full")
+		Expect(get()).To(Equal("b1"))
+		Expect(queue.Len()).To(Equal(0))
+
+		By("Handing out the backlog of a as its items are done")
+		q.Done("a1")
+		Expect(get()).To(Equal("a3"))
+		q.Done("b1")
+		q.Done("a2")
+		Expect(get()).To(Equal("a4"))
+	})
+})
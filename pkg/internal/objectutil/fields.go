@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectutil
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// MatchesFieldRequirements evaluates the given field requirements against the
+// object's content.  Like the API server, fields are compared as strings, and
+// missing fields have the empty string as value.
+func MatchesFieldRequirements(obj runtime.Object, reqs []fields.Requirement) (bool, error) {
+	var content map[string]interface{}
+	if u, isUnstructured := obj.(runtime.Unstructured); isUnstructured {
+		content = u.UnstructuredContent()
+	} else {
+		var err error
+		content, err = runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	for _, req := range reqs {
+		val, err := fieldValue(content, req.Field)
+		if err != nil {
+			return false, err
+		}
+		switch req.Operator {
+		case selection.Equals, selection.DoubleEquals:
+			if val != req.Value {
+				return false, nil
+			}
+		case selection.NotEquals:
+			if val == req.Value {
+				return false, nil
+			}
+		default:
+			return false, fmt.Errorf("unsupported operator %q in field selector for %q", req.Operator, req.Field)
+		}
+	}
+	return true, nil
+}
+
+// fieldValue returns the string representation of the scalar field at the
+// given dot-separated path.
+func fieldValue(content map[string]interface{}, field string) (string, error) {
+	val, found, err := unstructured.NestedFieldNoCopy(content, strings.Split(field, ".")...)
+	if err != nil {
+		return "", fmt.Errorf("unable to evaluate field selector for %q: %w", field, err)
+	}
+	if !found || val == nil {
+		return "", nil
+	}
+	switch v := val.(type) {
+	case string:
+		return v, nil
+	case bool, int64, float64:
+		return fmt.Sprint(v), nil
+	default:
+		return "", fmt.Errorf("field selector for %q does not refer to a scalar field, got %T", field, val)
+	}
+}
@@ -193,6 +193,12 @@ type Options struct {
 	// will only hold objects from the desired namespace.
 	Namespace string
 
+	// SelectorsByObject if specified restricts the manager's cache to only
+	// watch objects of the given types that match the label and field
+	// selectors. Objects filtered out are neither cached nor returned by the
+	// default client's reads. Defaults to watching every object.
+	SelectorsByObject cache.SelectorsByObject
+
 	// MetricsBindAddress is the TCP address that the controller should bind to
 	// for serving prometheus metrics.
 	// It can be set to "0" to disable the metrics serving.
@@ -308,6 +314,7 @@ func New(config *rest.Config, options Options) (Manager, error) {
 		clusterOptions.Logger = options.Logger
 		clusterOptions.SyncPeriod = options.SyncPeriod
 		clusterOptions.Namespace = options.Namespace
+		clusterOptions.SelectorsByObject = options.SelectorsByObject
 		clusterOptions.NewCache = options.NewCache
 		clusterOptions.NewClient = options.NewClient
 		clusterOptions.ClientDisableCacheFor = options.ClientDisableCacheFor
@@ -96,35 +96,37 @@ func init() {
 	Registry.MustRegister(longestRunningProcessor)
 	Registry.MustRegister(retries)
 
-	workqueue.SetProvider(workqueueMetricsProvider{})
+	workqueue.SetProvider(WorkqueueMetricsProvider{})
 }
 
-type workqueueMetricsProvider struct{}
+// WorkqueueMetricsProvider is the workqueue.MetricsProvider of the queues of
+// controllers.  It reports the workqueue metrics to Registry.
+type WorkqueueMetricsProvider struct{}
 
-func (workqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+func (WorkqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
 	return depth.WithLabelValues(name)
 }
 
-func (workqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+func (WorkqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
 	return adds.WithLabelValues(name)
 }
 
-func (workqueueMetricsProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
+func (WorkqueueMetricsProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
 	return latency.WithLabelValues(name)
 }
 
-func (workqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+func (WorkqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
 	return workDuration.WithLabelValues(name)
 }
 
-func (workqueueMetricsProvider) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+func (WorkqueueMetricsProvider) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
 	return unfinished.WithLabelValues(name)
 }
 
-func (workqueueMetricsProvider) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+func (WorkqueueMetricsProvider) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
 	return longestRunningProcessor.WithLabelValues(name)
 }
 
-func (workqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+func (WorkqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
 	return retries.WithLabelValues(name)
 }
@@ -29,44 +29,50 @@ import (
 var log = logf.RuntimeLog.WithName("predicate").WithName("eventFilters")
 
 // Predicate filters events before enqueuing the keys.
-type Predicate interface {
+type Predicate = TypedPredicate[client.Object]
+
+// TypedPredicate filters events for objects of type object before enqueuing the keys.
+type TypedPredicate[object any] interface {
 	// Create returns true if the Create event should be processed
-	Create(event.CreateEvent) bool
+	Create(event.TypedCreateEvent[object]) bool
 
 	// Delete returns true if the Delete event should be processed
-	Delete(event.DeleteEvent) bool
+	Delete(event.TypedDeleteEvent[object]) bool
 
 	// Update returns true if the Update event should be processed
-	Update(event.UpdateEvent) bool
+	Update(event.TypedUpdateEvent[object]) bool
 
 	// Generic returns true if the Generic event should be processed
-	Generic(event.GenericEvent) bool
+	Generic(event.TypedGenericEvent[object]) bool
 }
 
 var _ Predicate = Funcs{}
 var _ Predicate = ResourceVersionChangedPredicate{}
 var _ Predicate = GenerationChangedPredicate{}
 var _ Predicate = AnnotationChangedPredicate{}
-var _ Predicate = or{}
-var _ Predicate = and{}
+var _ Predicate = or[client.Object]{}
+var _ Predicate = and[client.Object]{}
 
 // Funcs is a function that implements Predicate.
-type Funcs struct {
+type Funcs = TypedFuncs[client.Object]
+
+// TypedFuncs is a function that implements TypedPredicate.
+type TypedFuncs[object any] struct {
 	// Create returns true if the Create event should be processed
-	CreateFunc func(event.CreateEvent) bool
+	CreateFunc func(event.TypedCreateEvent[object]) bool
 
 	// Delete returns true if the Delete event should be processed
-	DeleteFunc func(event.DeleteEvent) bool
+	DeleteFunc func(event.TypedDeleteEvent[object]) bool
 
 	// Update returns true if the Update event should be processed
-	UpdateFunc func(event.UpdateEvent) bool
+	UpdateFunc func(event.TypedUpdateEvent[object]) bool
 
 	// Generic returns true if the Generic event should be processed
-	GenericFunc func(event.GenericEvent) bool
+	GenericFunc func(event.TypedGenericEvent[object]) bool
 }
 
 // Create implements Predicate
-func (p Funcs) Create(e event.CreateEvent) bool {
+func (p TypedFuncs[object]) Create(e event.TypedCreateEvent[object]) bool {
 	if p.CreateFunc != nil {
 		return p.CreateFunc(e)
 	}
@@ -74,7 +80,7 @@ func (p Funcs) Create(e event.CreateEvent) bool {
 }
 
 // Delete implements Predicate
-func (p Funcs) Delete(e event.DeleteEvent) bool {
+func (p TypedFuncs[object]) Delete(e event.TypedDeleteEvent[object]) bool {
 	if p.DeleteFunc != nil {
 		return p.DeleteFunc(e)
 	}
@@ -82,7 +88,7 @@ func (p Funcs) Delete(e event.DeleteEvent) bool {
 }
 
 // Update implements Predicate
-func (p Funcs) Update(e event.UpdateEvent) bool {
+func (p TypedFuncs[object]) Update(e event.TypedUpdateEvent[object]) bool {
 	if p.UpdateFunc != nil {
 		return p.UpdateFunc(e)
 	}
@@ -90,7 +96,7 @@ func (p Funcs) Update(e event.UpdateEvent) bool {
 }
 
 // Generic implements Predicate
-func (p Funcs) Generic(e event.GenericEvent) bool {
+func (p TypedFuncs[object]) Generic(e event.TypedGenericEvent[object]) bool {
 	if p.GenericFunc != nil {
 		return p.GenericFunc(e)
 	}
@@ -101,17 +107,24 @@ func (p Funcs) Generic(e event.GenericEvent) bool {
 // on CREATE, UPDATE, DELETE and GENERIC events. For UPDATE events, the filter is applied
 // to the new object.
 func NewPredicateFuncs(filter func(object client.Object) bool) Funcs {
-	return Funcs{
-		CreateFunc: func(e event.CreateEvent) bool {
+	return NewTypedPredicateFuncs(filter)
+}
+
+// NewTypedPredicateFuncs returns a predicate funcs that applies the given filter function
+// on CREATE, UPDATE, DELETE and GENERIC events. For UPDATE events, the filter is applied
+// to the new object.
+func NewTypedPredicateFuncs[object any](filter func(obj object) bool) TypedFuncs[object] {
+	return TypedFuncs[object]{
+		CreateFunc: func(e event.TypedCreateEvent[object]) bool {
 			return filter(e.Object)
 		},
-		UpdateFunc: func(e event.UpdateEvent) bool {
+		UpdateFunc: func(e event.TypedUpdateEvent[object]) bool {
 			return filter(e.ObjectNew)
 		},
-		DeleteFunc: func(e event.DeleteEvent) bool {
+		DeleteFunc: func(e event.TypedDeleteEvent[object]) bool {
 			return filter(e.Object)
 		},
-		GenericFunc: func(e event.GenericEvent) bool {
+		GenericFunc: func(e event.TypedGenericEvent[object]) bool {
 			return filter(e.Object)
 		},
 	}
@@ -232,14 +245,19 @@ func (LabelChangedPredicate) Update(e event.UpdateEvent) bool {
 
 // And returns a composite predicate that implements a logical AND of the predicates passed to it.
 func And(predicates ...Predicate) Predicate {
-	return and{predicates}
+	return TypedAnd(predicates...)
+}
+
+// TypedAnd returns a composite predicate that implements a logical AND of the predicates passed to it.
+func TypedAnd[object any](predicates ...TypedPredicate[object]) TypedPredicate[object] {
+	return and[object]{predicates}
 }
 
-type and struct {
-	predicates []Predicate
+type and[object any] struct {
+	predicates []TypedPredicate[object]
 }
 
-func (a and) Create(e event.CreateEvent) bool {
+func (a and[object]) Create(e event.TypedCreateEvent[object]) bool {
 	for _, p := range a.predicates {
 		if !p.Create(e) {
 			return false
@@ -248,7 +266,7 @@ func (a and) Create(e event.CreateEvent) bool {
 	return true
 }
 
-func (a and) Update(e event.UpdateEvent) bool {
+func (a and[object]) Update(e event.TypedUpdateEvent[object]) bool {
 	for _, p := range a.predicates {
 		if !p.Update(e) {
 			return false
@@ -257,7 +275,7 @@ func (a and) Update(e event.UpdateEvent) bool {
 	return true
 }
 
-func (a and) Delete(e event.DeleteEvent) bool {
+func (a and[object]) Delete(e event.TypedDeleteEvent[object]) bool {
 	for _, p := range a.predicates {
 		if !p.Delete(e) {
 			return false
@@ -266,7 +284,7 @@ func (a and) Delete(e event.DeleteEvent) bool {
 	return true
 }
 
-func (a and) Generic(e event.GenericEvent) bool {
+func (a and[object]) Generic(e event.TypedGenericEvent[object]) bool {
 	for _, p := range a.predicates {
 		if !p.Generic(e) {
 			return false
@@ -277,14 +295,19 @@ func (a and) Generic(e event.GenericEvent) bool {
 
 // Or returns a composite predicate that implements a logical OR of the predicates passed to it.
 func Or(predicates ...Predicate) Predicate {
-	return or{predicates}
+	return TypedOr(predicates...)
+}
+
+// TypedOr returns a composite predicate that implements a logical OR of the predicates passed to it.
+func TypedOr[object any](predicates ...TypedPredicate[object]) TypedPredicate[object] {
+	return or[object]{predicates}
 }
 
-type or struct {
-	predicates []Predicate
+type or[object any] struct {
+	predicates []TypedPredicate[object]
 }
 
-func (o or) Create(e event.CreateEvent) bool {
+func (o or[object]) Create(e event.TypedCreateEvent[object]) bool {
 	for _, p := range o.predicates {
 		if p.Create(e) {
 			return true
@@ -293,7 +316,7 @@ func (o or) Create(e event.CreateEvent) bool {
 	return false
 }
 
-func (o or) Update(e event.UpdateEvent) bool {
+func (o or[object]) Update(e event.TypedUpdateEvent[object]) bool {
 	for _, p := range o.predicates {
 		if p.Update(e) {
 			return true
@@ -302,7 +325,7 @@ func (o or) Update(e event.UpdateEvent) bool {
 	return false
 }
 
-func (o or) Delete(e event.DeleteEvent) bool {
+func (o or[object]) Delete(e event.TypedDeleteEvent[object]) bool {
 	for _, p := range o.predicates {
 		if p.Delete(e) {
 			return true
@@ -311,7 +334,7 @@ func (o or) Delete(e event.DeleteEvent) bool {
 	return false
 }
 
-func (o or) Generic(e event.GenericEvent) bool {
+func (o or[object]) Generic(e event.TypedGenericEvent[object]) bool {
 	for _, p := range o.predicates {
 		if p.Generic(e) {
 			return true
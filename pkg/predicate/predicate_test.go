@@ -915,6 +915,29 @@ var _ = Describe("Predicate", func() {
 		})
 	})
 
+	Describe("When checking typed predicates", func() {
+		isFoo := predicate.NewTypedPredicateFuncs(func(pod *corev1.Pod) bool {
+			return pod.Spec.NodeName == "foo"
+		})
+		inBiz := predicate.NewTypedPredicateFuncs(func(pod *corev1.Pod) bool {
+			return pod.Namespace == "biz"
+		})
+
+		It("should pass typed objects to the filter", func() {
+			pod.Spec.NodeName = "foo"
+			Expect(isFoo.Create(event.TypedCreateEvent[*corev1.Pod]{Object: pod})).To(BeTrue())
+			Expect(isFoo.Delete(event.TypedDeleteEvent[*corev1.Pod]{Object: pod})).To(BeTrue())
+			Expect(isFoo.Generic(event.TypedGenericEvent[*corev1.Pod]{Object: pod})).To(BeTrue())
+			Expect(isFoo.Update(event.TypedUpdateEvent[*corev1.Pod]{ObjectOld: &corev1.Pod{}, ObjectNew: pod})).To(BeTrue())
+			Expect(isFoo.Update(event.TypedUpdateEvent[*corev1.Pod]{ObjectOld: pod, ObjectNew: &corev1.Pod{}})).To(BeFalse())
+		})
+
+		It("should combine typed predicates", func() {
+			Expect(predicate.TypedAnd[*corev1.Pod](isFoo, inBiz).Create(event.TypedCreateEvent[*corev1.Pod]{Object: pod})).To(BeFalse())
+			Expect(predicate.TypedOr[*corev1.Pod](isFoo, inBiz).Create(event.TypedCreateEvent[*corev1.Pod]{Object: pod})).To(BeTrue())
+		})
+	})
+
 	Describe("When checking a LabelSelectorPredicate", func() {
 		instance, err := predicate.LabelSelectorPredicate(metav1.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}})
 		if err != nil {
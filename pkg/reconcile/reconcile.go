@@ -18,6 +18,7 @@ package reconcile
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"k8s.io/apimachinery/pkg/types"
@@ -86,17 +87,54 @@ driven by actual cluster state read from the apiserver or a local cache.
 For example if responding to a Pod Delete Event, the Request won't contain that a Pod was deleted,
 instead the reconcile function observes this when reading the cluster state and seeing the Pod as missing.
 */
-type Reconciler interface {
+type Reconciler = TypedReconciler[Request]
+
+// TypedReconciler is a Reconciler of requests of type request, which may carry more than the Name / Namespace
+// of an object, e.g. the name of a cluster or the ID of an external resource.  Requests are compared to
+// batch identical requests together in the queue, so they must be comparable.
+type TypedReconciler[request comparable] interface {
 	// Reconciler performs a full reconciliation for the object referred to by the Request.
-	// The Controller will requeue the Request to be processed again if an error is non-nil or
-	// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
-	Reconcile(context.Context, Request) (Result, error)
+	// The Controller will requeue the Request to be processed again if an error is non-nil, unless it
+	// is a TerminalError, or Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+	Reconcile(context.Context, request) (Result, error)
 }
 
 // Func is a function that implements the reconcile interface.
-type Func func(context.Context, Request) (Result, error)
+type Func = TypedFunc[Request]
+
+// TypedFunc is a function that implements the TypedReconciler interface.
+type TypedFunc[request comparable] func(context.Context, request) (Result, error)
 
 var _ Reconciler = Func(nil)
 
 // Reconcile implements Reconciler.
-func (r Func) Reconcile(ctx context.Context, o Request) (Result, error) { return r(ctx, o) }
+func (r TypedFunc[request]) Reconcile(ctx context.Context, o request) (Result, error) { return r(ctx, o) }
+
+// TerminalError wraps an error to tell the Controller that retrying the Request won't help, e.g. because
+// the object is invalid.  The Controller reports the error, but doesn't requeue the Request: it is only
+// reconciled again on the next event for the object.  errors.Is and errors.As see through the wrapper.
+func TerminalError(err error) error {
+	return &terminalError{err: err}
+}
+
+type terminalError struct {
+	err error
+}
+
+// Unwrap returns the wrapped error.
+func (te *terminalError) Unwrap() error {
+	return te.err
+}
+
+func (te *terminalError) Error() string {
+	if te.err == nil {
+		return "nil terminal error"
+	}
+	return "terminal error: " + te.err.Error()
+}
+
+// IsTerminalError returns true if err, or an error it wraps, was returned by TerminalError.
+func IsTerminalError(err error) bool {
+	var te *terminalError
+	return errors.As(err, &te)
+}
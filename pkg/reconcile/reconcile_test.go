@@ -18,6 +18,7 @@ package reconcile_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -89,4 +90,19 @@ var _ = Describe("reconcile", func() {
 			Expect(actualErr).To(Equal(err))
 		})
 	})
+
+	Describe("TerminalError", func() {
+		It("should be recognized through wrapping", func() {
+			inner := fmt.Errorf("invalid spec")
+			err := fmt.Errorf("failed to reconcile: %w", reconcile.TerminalError(inner))
+			Expect(reconcile.IsTerminalError(err)).To(BeTrue())
+			Expect(errors.Is(err, inner)).To(BeTrue())
+			Expect(err.Error()).To(Equal("failed to reconcile: terminal error: invalid spec"))
+		})
+
+		It("should not match other errors", func() {
+			Expect(reconcile.IsTerminalError(fmt.Errorf("transient"))).To(BeFalse())
+			Expect(reconcile.IsTerminalError(nil)).To(BeFalse())
+		})
+	})
 })
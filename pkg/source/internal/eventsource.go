@@ -18,10 +18,13 @@ package internal
 
 import (
 	"fmt"
+	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
@@ -31,21 +34,36 @@ import (
 
 var log = logf.RuntimeLog.WithName("source").WithName("EventHandler")
 
-var _ cache.ResourceEventHandler = EventHandler{}
+var _ cache.ResourceEventHandler = EventHandler[client.Object]{}
 
-// EventHandler adapts a handler.EventHandler interface to a cache.ResourceEventHandler interface
-type EventHandler struct {
-	EventHandler handler.EventHandler
+// EventHandler adapts a handler.TypedEventHandler interface to a cache.ResourceEventHandler interface
+type EventHandler[object any] struct {
+	EventHandler handler.TypedEventHandler[object]
 	Queue        workqueue.RateLimitingInterface
-	Predicates   []predicate.Predicate
+	Predicates   []predicate.TypedPredicate[object]
+
+	// StartedAt is the time the source was started.  Objects created before
+	// it are considered part of the initial list.
+	StartedAt time.Time
+
+	// OnRemoved, if set, is called when the informer this handler was added
+	// to is removed from the cache.
+	OnRemoved func()
+}
+
+// OnInformerRemoved implements cache.InformerRemovedHandler and calls OnRemoved
+func (e EventHandler[object]) OnInformerRemoved() {
+	if e.OnRemoved != nil {
+		e.OnRemoved()
+	}
 }
 
 // OnAdd creates CreateEvent and calls Create on EventHandler
-func (e EventHandler) OnAdd(obj interface{}) {
-	c := event.CreateEvent{}
+func (e EventHandler[object]) OnAdd(obj interface{}) {
+	c := event.TypedCreateEvent[object]{}
 
 	// Pull Object out of the object
-	if o, ok := obj.(client.Object); ok {
+	if o, ok := obj.(object); ok {
 		c.Object = o
 	} else {
 		log.Error(nil, "OnAdd missing Object",
@@ -53,6 +71,11 @@ func (e EventHandler) OnAdd(obj interface{}) {
 		return
 	}
 
+	// The creation timestamp only has a precision of a second.
+	if m, ok := any(c.Object).(metav1.Object); ok && !e.StartedAt.IsZero() {
+		c.IsInInitialList = m.GetCreationTimestamp().Time.Before(e.StartedAt.Truncate(time.Second))
+	}
+
 	for _, p := range e.Predicates {
 		if !p.Create(c) {
 			return
@@ -60,14 +83,18 @@ func (e EventHandler) OnAdd(obj interface{}) {
 	}
 
 	// Invoke create handler
-	e.EventHandler.Create(c, e.Queue)
+	queue := e.Queue
+	if c.IsInInitialList {
+		queue = lowPriority(queue)
+	}
+	e.EventHandler.Create(c, queue)
 }
 
 // OnUpdate creates UpdateEvent and calls Update on EventHandler
-func (e EventHandler) OnUpdate(oldObj, newObj interface{}) {
-	u := event.UpdateEvent{}
+func (e EventHandler[object]) OnUpdate(oldObj, newObj interface{}) {
+	u := event.TypedUpdateEvent[object]{}
 
-	if o, ok := oldObj.(client.Object); ok {
+	if o, ok := oldObj.(object); ok {
 		u.ObjectOld = o
 	} else {
 		log.Error(nil, "OnUpdate missing ObjectOld",
@@ -76,7 +103,7 @@ func (e EventHandler) OnUpdate(oldObj, newObj interface{}) {
 	}
 
 	// Pull Object out of the object
-	if o, ok := newObj.(client.Object); ok {
+	if o, ok := newObj.(object); ok {
 		u.ObjectNew = o
 	} else {
 		log.Error(nil, "OnUpdate missing ObjectNew",
@@ -91,12 +118,16 @@ func (e EventHandler) OnUpdate(oldObj, newObj interface{}) {
 	}
 
 	// Invoke update handler
-	e.EventHandler.Update(u, e.Queue)
+	queue := e.Queue
+	if isResync(u.ObjectOld, u.ObjectNew) {
+		queue = lowPriority(queue)
+	}
+	e.EventHandler.Update(u, queue)
 }
 
 // OnDelete creates DeleteEvent and calls Delete on EventHandler
-func (e EventHandler) OnDelete(obj interface{}) {
-	d := event.DeleteEvent{}
+func (e EventHandler[object]) OnDelete(obj interface{}) {
+	d := event.TypedDeleteEvent[object]{}
 
 	// Deal with tombstone events by pulling the object out.  Tombstone events wrap the object in a
 	// DeleteFinalStateUnknown struct, so the object needs to be pulled out.
@@ -104,8 +135,8 @@ func (e EventHandler) OnDelete(obj interface{}) {
 	// This should never happen if we aren't missing events, which we have concluded that we are not
 	// and made decisions off of this belief.  Maybe this shouldn't be here?
 	var ok bool
-	if _, ok = obj.(client.Object); !ok {
-		// If the object doesn't have Metadata, assume it is a tombstone object of type DeletedFinalStateUnknown
+	if _, ok = obj.(object); !ok {
+		// If the object isn't of the expected type, assume it is a tombstone object of type DeletedFinalStateUnknown
 		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
 		if !ok {
 			log.Error(nil, "Error decoding objects.  Expected cache.DeletedFinalStateUnknown",
@@ -119,7 +150,7 @@ func (e EventHandler) OnDelete(obj interface{}) {
 	}
 
 	// Pull Object out of the object
-	if o, ok := obj.(client.Object); ok {
+	if o, ok := obj.(object); ok {
 		d.Object = o
 	} else {
 		log.Error(nil, "OnDelete missing Object",
@@ -136,3 +167,43 @@ func (e EventHandler) OnDelete(obj interface{}) {
 	// Invoke delete handler
 	e.EventHandler.Delete(d, e.Queue)
 }
+
+// isResync returns whether an update is a resync of an unchanged object.
+func isResync(oldObj, newObj interface{}) bool {
+	o, ok := oldObj.(metav1.Object)
+	if !ok {
+		return false
+	}
+	n, ok := newObj.(metav1.Object)
+	if !ok {
+		return false
+	}
+	return o.GetResourceVersion() != "" && o.GetResourceVersion() == n.GetResourceVersion()
+}
+
+// lowPriority returns a queue adding items with priorityqueue.LowPriority
+// by default if queue is a priorityqueue.PriorityQueue, and queue otherwise.
+func lowPriority(queue workqueue.RateLimitingInterface) workqueue.RateLimitingInterface {
+	if pq, ok := queue.(priorityqueue.PriorityQueue); ok {
+		return lowPriorityQueue{pq}
+	}
+	return queue
+}
+
+// lowPriorityQueue adds items with priorityqueue.LowPriority, unless
+// AddWithOpts is used.
+type lowPriorityQueue struct {
+	priorityqueue.PriorityQueue
+}
+
+func (q lowPriorityQueue) Add(item interface{}) {
+	q.AddWithOpts(priorityqueue.AddOpts{Priority: priorityqueue.LowPriority}, item)
+}
+
+func (q lowPriorityQueue) AddAfter(item interface{}, duration time.Duration) {
+	q.AddWithOpts(priorityqueue.AddOpts{After: duration, Priority: priorityqueue.LowPriority}, item)
+}
+
+func (q lowPriorityQueue) AddRateLimited(item interface{}) {
+	q.AddWithOpts(priorityqueue.AddOpts{RateLimited: true, Priority: priorityqueue.LowPriority}, item)
+}
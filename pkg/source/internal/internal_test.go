@@ -17,10 +17,13 @@ limitations under the License.
 package internal_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/source/internal"
@@ -30,12 +33,14 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 var _ = Describe("Internal", func() {
 
-	var instance internal.EventHandler
+	var instance internal.EventHandler[client.Object]
 	var funcs, setfuncs *handler.Funcs
 	var set bool
 	BeforeEach(func() {
@@ -72,7 +77,7 @@ var _ = Describe("Internal", func() {
 				set = true
 			},
 		}
-		instance = internal.EventHandler{
+		instance = internal.EventHandler[client.Object]{
 			Queue:        controllertest.Queue{},
 			EventHandler: funcs,
 		}
@@ -102,7 +107,7 @@ var _ = Describe("Internal", func() {
 		})
 
 		It("should used Predicates to filter CreateEvents", func(done Done) {
-			instance = internal.EventHandler{
+			instance = internal.EventHandler[client.Object]{
 				Queue:        controllertest.Queue{},
 				EventHandler: setfuncs,
 			}
@@ -171,7 +176,7 @@ var _ = Describe("Internal", func() {
 		})
 
 		It("should used Predicates to filter UpdateEvents", func(done Done) {
-			instance = internal.EventHandler{
+			instance = internal.EventHandler[client.Object]{
 				Queue:        controllertest.Queue{},
 				EventHandler: setfuncs,
 			}
@@ -241,7 +246,7 @@ var _ = Describe("Internal", func() {
 		})
 
 		It("should used Predicates to filter DeleteEvents", func(done Done) {
-			instance = internal.EventHandler{
+			instance = internal.EventHandler[client.Object]{
 				Queue:        controllertest.Queue{},
 				EventHandler: setfuncs,
 			}
@@ -323,6 +328,62 @@ var _ = Describe("Internal", func() {
 			instance.OnDelete(Foo{})
 			close(done)
 		})
+		It("should mark objects created before the start as in the initial list", func(done Done) {
+			instance.StartedAt = time.Now()
+			pod.CreationTimestamp = metav1.NewTime(instance.StartedAt.Add(-time.Minute))
+			newPod.CreationTimestamp = metav1.NewTime(instance.StartedAt.Add(time.Second))
+
+			var inInitialList []bool
+			funcs.CreateFunc = func(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+				inInitialList = append(inInitialList, evt.IsInInitialList)
+			}
+			instance.OnAdd(pod)
+			instance.OnAdd(newPod)
+			Expect(inInitialList).To(Equal([]bool{true, false}))
+			close(done)
+		})
+
+		It("should add the requests for the initial list and for resyncs with a low priority", func(done Done) {
+			queue := priorityqueue.New("test", workqueue.DefaultControllerRateLimiter())
+			defer queue.ShutDown()
+			instance.Queue = queue
+			instance.EventHandler = &handler.EnqueueRequestForObject{}
+			instance.StartedAt = time.Now()
+
+			initial := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name: "initial", CreationTimestamp: metav1.NewTime(instance.StartedAt.Add(-time.Minute)),
+			}}
+			resynced := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name: "resynced", ResourceVersion: "1", CreationTimestamp: metav1.NewTime(instance.StartedAt.Add(-time.Minute)),
+			}}
+			created := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name: "created", CreationTimestamp: metav1.NewTime(instance.StartedAt.Add(time.Second)),
+			}}
+			instance.OnAdd(initial)
+			instance.OnUpdate(resynced, resynced)
+			instance.OnAdd(created)
+
+			var names []string
+			for queue.Len() > 0 {
+				item, _ := queue.Get()
+				queue.Done(item)
+				names = append(names, item.(reconcile.Request).Name)
+			}
+			Expect(names).To(Equal([]string{"created", "initial", "resynced"}))
+			close(done)
+		})
+
+		It("should call OnRemoved when the informer is removed", func(done Done) {
+			removed := false
+			instance.OnRemoved = func() { removed = true }
+			instance.OnInformerRemoved()
+			Expect(removed).To(BeTrue())
+			close(done)
+		})
+		It("should ignore informer removal if OnRemoved is not set", func(done Done) {
+			instance.OnInformerRemoved()
+			close(done)
+		})
 	})
 })
 
@@ -20,7 +20,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/util/workqueue"
@@ -51,10 +53,15 @@ const (
 //
 // Users may build their own Source implementations.  If their implementations implement any of the inject package
 // interfaces, the dependencies will be injected by the Controller when Watch is called.
-type Source interface {
+type Source = TypedSource[client.Object]
+
+// TypedSource is a source of events for objects of type object, which should be processed by
+// handler.TypedEventHandlers to enqueue requests.  Sources of Kubernetes objects usually use client.Object, but
+// sources of events originating outside the cluster may use any type.
+type TypedSource[object any] interface {
 	// Start is internal and should be called only by the Controller to register an EventHandler with the Informer
-	// to enqueue reconcile.Requests.
-	Start(context.Context, handler.EventHandler, workqueue.RateLimitingInterface, ...predicate.Predicate) error
+	// to enqueue requests.
+	Start(context.Context, handler.TypedEventHandler[object], workqueue.RateLimitingInterface, ...predicate.TypedPredicate[object]) error
 }
 
 // SyncingSource is a source that needs syncing prior to being usable. The controller
@@ -85,9 +92,16 @@ func (ks *kindWithCache) WaitForSync(ctx context.Context) error {
 }
 
 // Kind is used to provide a source of events originating inside the cluster from Watches (e.g. Pod Create)
-type Kind struct {
+type Kind = TypedKind[client.Object]
+
+// TypedKind is Kind for objects of type object, e.g. *v1.Pod, so that handlers and predicates get objects of
+// that type instead of client.Object.
+//
+// If the informer of the source is removed from the cache with RemoveInformer, the source receives no more
+// events, and WaitForSync returns an error so that the controller doesn't start with it.
+type TypedKind[object client.Object] struct {
 	// Type is the type of object to watch.  e.g. &v1.Pod{}
-	Type client.Object
+	Type object
 
 	// cache used to watch APIs
 	cache cache.Cache
@@ -96,17 +110,23 @@ type Kind struct {
 	// contain an error, startup and syncing finished.
 	started     chan error
 	startCancel func()
+
+	// removed is closed once the informer has been removed from the cache.
+	removed     chan struct{}
+	removedOnce sync.Once
 }
 
 var _ SyncingSource = &Kind{}
 
+var _ cache.InformerRemovedHandler = internal.EventHandler[client.Object]{}
+
 // Start is internal and should be called only by the Controller to register an EventHandler with the Informer
 // to enqueue reconcile.Requests.
-func (ks *Kind) Start(ctx context.Context, handler handler.EventHandler, queue workqueue.RateLimitingInterface,
-	prct ...predicate.Predicate) error {
+func (ks *TypedKind[object]) Start(ctx context.Context, handler handler.TypedEventHandler[object], queue workqueue.RateLimitingInterface,
+	prct ...predicate.TypedPredicate[object]) error {
 
 	// Type should have been specified by the user.
-	if ks.Type == nil {
+	if ks.typeIsNil() {
 		return fmt.Errorf("must specify Kind.Type")
 	}
 
@@ -119,6 +139,8 @@ func (ks *Kind) Start(ctx context.Context, handler handler.EventHandler, queue w
 	// sync that informer (most commonly due to RBAC issues).
 	ctx, ks.startCancel = context.WithCancel(ctx)
 	ks.started = make(chan error)
+	ks.removed = make(chan struct{})
+	startedAt := time.Now()
 	go func() {
 		// Lookup the Informer from the Cache and add an EventHandler which populates the Queue
 		i, err := ks.cache.GetInformer(ctx, ks.Type)
@@ -131,7 +153,13 @@ func (ks *Kind) Start(ctx context.Context, handler handler.EventHandler, queue w
 			ks.started <- err
 			return
 		}
-		i.AddEventHandler(internal.EventHandler{Queue: queue, EventHandler: handler, Predicates: prct})
+		i.AddEventHandler(internal.EventHandler[object]{Queue: queue, EventHandler: handler, Predicates: prct, StartedAt: startedAt, OnRemoved: func() {
+			// Handlers added to several informers, e.g. one per namespace, are notified once by each of them.
+			ks.removedOnce.Do(func() {
+				log.Info("informer removed from the cache, no more events will be received", "source", ks.String())
+				close(ks.removed)
+			})
+		}})
 		if !ks.cache.WaitForCacheSync(ctx) {
 			// Would be great to return something more informative here
 			ks.started <- errors.New("cache did not sync")
@@ -142,30 +170,52 @@ func (ks *Kind) Start(ctx context.Context, handler handler.EventHandler, queue w
 	return nil
 }
 
-func (ks *Kind) String() string {
-	if ks.Type != nil && ks.Type.GetObjectKind() != nil {
+func (ks *TypedKind[object]) String() string {
+	if !ks.typeIsNil() && ks.Type.GetObjectKind() != nil {
 		return fmt.Sprintf("kind source: %v", ks.Type.GetObjectKind().GroupVersionKind().String())
 	}
 	return fmt.Sprintf("kind source: unknown GVK")
 }
 
+// typeIsNil returns true if Type wasn't set.  Type can be an interface, or a
+// pointer type which is nil in the zero value of TypedKind.
+func (ks *TypedKind[object]) typeIsNil() bool {
+	v := reflect.ValueOf(ks.Type)
+	return !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil())
+}
+
 // WaitForSync implements SyncingSource to allow controllers to wait with starting
-// workers until the cache is synced.
-func (ks *Kind) WaitForSync(ctx context.Context) error {
+// workers until the cache is synced.  It returns an error if the informer was removed from the cache.
+func (ks *TypedKind[object]) WaitForSync(ctx context.Context) error {
 	select {
 	case err := <-ks.started:
+		if err == nil {
+			err = ks.removedErr()
+		}
 		return err
+	case <-ks.removed:
+		return ks.removedErr()
 	case <-ctx.Done():
 		ks.startCancel()
 		return errors.New("timed out waiting for cache to be synced")
 	}
 }
 
+// removedErr returns an error if the informer was removed from the cache.
+func (ks *TypedKind[object]) removedErr() error {
+	select {
+	case <-ks.removed:
+		return fmt.Errorf("%s: informer was removed from the cache", ks.String())
+	default:
+		return nil
+	}
+}
+
 var _ inject.Cache = &Kind{}
 
 // InjectCache is internal should be called only by the Controller.  InjectCache is used to inject
 // the Cache dependency initialized by the ControllerManager.
-func (ks *Kind) InjectCache(c cache.Cache) error {
+func (ks *TypedKind[object]) InjectCache(c cache.Cache) error {
 	if ks.cache == nil {
 		ks.cache = c
 	}
@@ -177,18 +227,22 @@ var _ Source = &Channel{}
 // Channel is used to provide a source of events originating outside the cluster
 // (e.g. GitHub Webhook callback).  Channel requires the user to wire the external
 // source (eh.g. http handler) to write GenericEvents to the underlying channel.
-type Channel struct {
+type Channel = TypedChannel[client.Object]
+
+// TypedChannel is Channel for events for objects of type object, which don't have to be
+// Kubernetes objects.
+type TypedChannel[object any] struct {
 	// once ensures the event distribution goroutine will be performed only once
 	once sync.Once
 
 	// Source is the source channel to fetch GenericEvents
-	Source <-chan event.GenericEvent
+	Source <-chan event.TypedGenericEvent[object]
 
 	// stop is to end ongoing goroutine, and close the channels
 	stop <-chan struct{}
 
 	// dest is the destination channels of the added event handlers
-	dest []chan event.GenericEvent
+	dest []chan event.TypedGenericEvent[object]
 
 	// DestBufferSize is the specified buffer size of dest channels.
 	// Default to 1024 if not specified.
@@ -198,7 +252,7 @@ type Channel struct {
 	destLock sync.Mutex
 }
 
-func (cs *Channel) String() string {
+func (cs *TypedChannel[object]) String() string {
 	return fmt.Sprintf("channel source: %p", cs)
 }
 
@@ -206,7 +260,7 @@ var _ inject.Stoppable = &Channel{}
 
 // InjectStopChannel is internal should be called only by the Controller.
 // It is used to inject the stop channel initialized by the ControllerManager.
-func (cs *Channel) InjectStopChannel(stop <-chan struct{}) error {
+func (cs *TypedChannel[object]) InjectStopChannel(stop <-chan struct{}) error {
 	if cs.stop == nil {
 		cs.stop = stop
 	}
@@ -215,11 +269,11 @@ func (cs *Channel) InjectStopChannel(stop <-chan struct{}) error {
 }
 
 // Start implements Source and should only be called by the Controller.
-func (cs *Channel) Start(
+func (cs *TypedChannel[object]) Start(
 	ctx context.Context,
-	handler handler.EventHandler,
+	handler handler.TypedEventHandler[object],
 	queue workqueue.RateLimitingInterface,
-	prct ...predicate.Predicate) error {
+	prct ...predicate.TypedPredicate[object]) error {
 	// Source should have been specified by the user.
 	if cs.Source == nil {
 		return fmt.Errorf("must specify Channel.Source")
@@ -235,7 +289,7 @@ func (cs *Channel) Start(
 		cs.DestBufferSize = defaultBufferSize
 	}
 
-	dst := make(chan event.GenericEvent, cs.DestBufferSize)
+	dst := make(chan event.TypedGenericEvent[object], cs.DestBufferSize)
 
 	cs.destLock.Lock()
 	cs.dest = append(cs.dest, dst)
@@ -265,7 +319,7 @@ func (cs *Channel) Start(
 	return nil
 }
 
-func (cs *Channel) doStop() {
+func (cs *TypedChannel[object]) doStop() {
 	cs.destLock.Lock()
 	defer cs.destLock.Unlock()
 
@@ -274,7 +328,7 @@ func (cs *Channel) doStop() {
 	}
 }
 
-func (cs *Channel) distribute(evt event.GenericEvent) {
+func (cs *TypedChannel[object]) distribute(evt event.TypedGenericEvent[object]) {
 	cs.destLock.Lock()
 	defer cs.destLock.Unlock()
 
@@ -288,7 +342,7 @@ func (cs *Channel) distribute(evt event.GenericEvent) {
 	}
 }
 
-func (cs *Channel) syncLoop(ctx context.Context) {
+func (cs *TypedChannel[object]) syncLoop(ctx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
@@ -325,7 +379,7 @@ func (is *Informer) Start(ctx context.Context, handler handler.EventHandler, que
 		return fmt.Errorf("must specify Informer.Informer")
 	}
 
-	is.Informer.AddEventHandler(internal.EventHandler{Queue: queue, EventHandler: handler, Predicates: prct})
+	is.Informer.AddEventHandler(internal.EventHandler[client.Object]{Queue: queue, EventHandler: handler, Predicates: prct, StartedAt: time.Now()})
 	return nil
 }
 
@@ -336,14 +390,17 @@ func (is *Informer) String() string {
 var _ Source = Func(nil)
 
 // Func is a function that implements Source
-type Func func(context.Context, handler.EventHandler, workqueue.RateLimitingInterface, ...predicate.Predicate) error
+type Func = TypedFunc[client.Object]
+
+// TypedFunc is a function that implements TypedSource
+type TypedFunc[object any] func(context.Context, handler.TypedEventHandler[object], workqueue.RateLimitingInterface, ...predicate.TypedPredicate[object]) error
 
 // Start implements Source
-func (f Func) Start(ctx context.Context, evt handler.EventHandler, queue workqueue.RateLimitingInterface,
-	pr ...predicate.Predicate) error {
+func (f TypedFunc[object]) Start(ctx context.Context, evt handler.TypedEventHandler[object], queue workqueue.RateLimitingInterface,
+	pr ...predicate.TypedPredicate[object]) error {
 	return f(ctx, evt, queue, pr...)
 }
 
-func (f Func) String() string {
+func (f TypedFunc[object]) String() string {
 	return fmt.Sprintf("func source: %p", f)
 }
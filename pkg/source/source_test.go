@@ -210,6 +210,17 @@ var _ = Describe("Source", func() {
 			close(done)
 		})
 
+		It("should return an error from Start if the zero value of a TypedKind was used", func(done Done) {
+			instance := &source.TypedKind[*corev1.Pod]{}
+			Expect(instance.InjectCache(&informertest.FakeInformers{})).To(Succeed())
+			err := instance.Start(ctx, nil, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must specify Kind.Type"))
+			Expect(instance.String()).To(Equal("kind source: unknown GVK"))
+
+			close(done)
+		})
+
 		It("should return an error if syncing fails", func(done Done) {
 			instance := source.Kind{Type: &corev1.Pod{}}
 			f := false
@@ -223,6 +234,21 @@ var _ = Describe("Source", func() {
 
 		})
 
+		It("should return an error from WaitForSync once the informer is removed", func(done Done) {
+			q := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "test")
+			instance := &source.Kind{Type: &corev1.Pod{}}
+			Expect(instance.InjectCache(ic)).To(Succeed())
+			Expect(instance.Start(ctx, handler.Funcs{}, q)).To(Succeed())
+			Expect(instance.WaitForSync(context.Background())).To(Succeed())
+
+			Expect(ic.RemoveInformer(ctx, &corev1.Pod{})).To(Succeed())
+			err := instance.WaitForSync(context.Background())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("informer was removed from the cache"))
+
+			close(done)
+		})
+
 		Context("for a Kind not in the cache", func() {
 			It("should return an error when WaitForSync is called", func(done Done) {
 				ic.Error = fmt.Errorf("test error")